@@ -0,0 +1,167 @@
+package analysis
+
+import (
+	"errors"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/booldesign/gvalid"
+	"github.com/booldesign/gvalid/internal/gotypes"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 14:00
+ * @Desc: go vet 检查 valid tag
+ */
+
+const doc = `check valid tags of github.com/booldesign/gvalid
+
+检查 valid tag 的语法, 未知的规则, 错误的参数, 不支持的字段类型以及不能 dive 的字段.
+enum=Name 和 sensitive=Name 的参数在运行时注册, 不检查.`
+
+// Analyzer 检查 valid tag, 与 gvalid.Compile 使用相同的解析和检查
+var Analyzer = &analysis.Analyzer{
+	Name:     "gvalid",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var custom string
+
+func init() {
+	Analyzer.Flags.StringVar(&custom, "custom", "", "通过 RegisterCustomTypeFunc 注册的类型, 逗号分隔, 如 example.com/money.Money, 不检查其类型")
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	customTypes := make(map[string]struct{})
+	for _, s := range strings.Split(custom, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			customTypes[s] = struct{}{}
+		}
+	}
+
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			checkField(pass, field, customTypes)
+		}
+	})
+	return nil, nil
+}
+
+func checkField(pass *analysis.Pass, field *ast.Field, customTypes map[string]struct{}) {
+	lit, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return
+	}
+	tag, ok := reflect.StructTag(lit).Lookup("valid")
+	if !ok || tag == "" || tag == "-" {
+		return
+	}
+
+	rules, err := gvalid.ParseTag(tag)
+	if err != nil {
+		var te *gvalid.TagError
+		if errors.As(err, &te) {
+			pass.Reportf(tagPos(field.Tag, te.Column), "%s", te.Msg)
+		} else {
+			pass.Reportf(field.Tag.Pos(), "%v", err)
+		}
+		return
+	}
+
+	var ft *gvalid.FieldType
+	if t := pass.TypesInfo.TypeOf(field.Type); t != nil {
+		ft = gotypes.FieldType(t)
+		if _, ok = customTypes[gotypes.TypeName(t)]; ok {
+			ft.Custom = true
+		}
+	}
+	for _, r := range rules {
+		// 运行时注册的参数不检查
+		if (r.Name == "enum" || r.Name == gvalid.SensitiveTag) && len(r.Params) > 0 {
+			continue
+		}
+		if err = gvalid.CheckRule(r, ft); err != nil {
+			pass.Reportf(tagPos(field.Tag, r.Column), "%v", err)
+		}
+	}
+}
+
+// tagPos valid tag 第 column 个字符的位置, 无法确定时为 tag 的位置
+func tagPos(lit *ast.BasicLit, column int) token.Pos {
+	raw := lit.Value
+	// 只有反引号的 tag 与源码逐字对应
+	if !strings.HasPrefix(raw, "`") {
+		return lit.Pos()
+	}
+	offset := valueOffset(raw[1:len(raw)-1], "valid")
+	if offset < 0 {
+		return lit.Pos()
+	}
+	// 跳过 tag 值中的转义, 如 \\d 在 tag 中为 \d
+	quoted := raw[1+offset:]
+	i := 0
+	for n := 1; n < column && i < len(quoted) && quoted[i] != '"'; n++ {
+		if quoted[i] == '\\' {
+			i++
+		}
+		_, size := utf8.DecodeRuneInString(quoted[i:])
+		i += size
+	}
+	return lit.Pos() + token.Pos(1+offset+i)
+}
+
+// valueOffset tag 中 key 的值 (引号之后) 的偏移, 同 reflect.StructTag.Lookup
+func valueOffset(tag, key string) int {
+	pos := 0
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		pos, tag = pos+i, tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		name := tag[:i]
+		pos, tag = pos+i+1, tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		if name == key {
+			return pos + 1
+		}
+		pos, tag = pos+i+1, tag[i+1:]
+	}
+	return -1
+}
@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 14:40
+ * @Desc:
+ */
+
+func TestAnalyzer(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+
+	// 诊断位置为规则在源码中的位置
+	src, err := os.ReadFile(filepath.Join(analysistest.TestData(), "src", "a", "a.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(src), "\n")
+	want := map[string]string{
+		"gt 的参数 0.5 应为整数": "gt=0.5",
+		"引号未闭合":           "'a",
+		"正则表达式错误":         "(/[/)",
+	}
+	for _, r := range results {
+		for _, d := range r.Diagnostics {
+			for msg, rule := range want {
+				if !strings.HasPrefix(d.Message, msg) {
+					continue
+				}
+				pos := r.Pass.Fset.Position(d.Pos)
+				if col := strings.Index(lines[pos.Line-1], rule) + 1; pos.Column != col {
+					t.Errorf("%s: column = %d, want %d", d.Message, pos.Column, col)
+				}
+			}
+		}
+	}
+}
+
+func TestValueOffset(t *testing.T) {
+	cases := []struct {
+		tag    string
+		offset int
+	}{
+		{`valid:"required"`, 7},
+		{`json:"name" valid:"required"`, 19},
+		{`json:"a\"b"  valid:"x"`, 20},
+		{`xvalid:"a" valid:"b"`, 18},
+		{`json:"name"`, -1},
+	}
+	for _, c := range cases {
+		if got := valueOffset(c.tag, "valid"); got != c.offset {
+			t.Errorf("valueOffset(%q) = %d, want %d", c.tag, got, c.offset)
+		}
+	}
+}
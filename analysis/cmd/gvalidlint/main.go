@@ -0,0 +1,17 @@
+package main
+
+import (
+	"github.com/booldesign/gvalid/analysis"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 14:30
+ * @Desc: go vet -vettool=$(which gvalidlint) ./...
+ */
+
+func main() {
+	singlechecker.Main(analysis.Analyzer)
+}
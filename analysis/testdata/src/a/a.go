@@ -0,0 +1,29 @@
+package a
+
+import "database/sql"
+
+type Status int
+
+func (s Status) IsValid() bool { return s > 0 }
+
+type Item struct {
+	Name string `valid:"required"`
+}
+
+type Order struct {
+	Name    string         `json:"name" valid:"requird,gt=0.5"`   // want `未知的规则 requird` `gt 的参数 0.5 应为整数`
+	Age     int            `valid:"len=10"`                       // want `len 不支持 int 类型`
+	Tags    []float64      `valid:"sin=1 2"`                      // want `sin 不支持 \[\]float64 类型`
+	Code    string         `valid:"required,in='a"`               // want `引号未闭合`
+	Email   *string        `valid:"email,required=1"`             // want `required 不需要参数`
+	Count   int            `valid:"dive"`                         // want `dive 不支持 int 类型`
+	Kind    string         `valid:"enum"`                         // want `string 没有实现 Enum 接口`
+	Regex   string         `valid:"regex=(/^\\d+$/),regex=(/[/)"` // want `正则表达式错误`
+	Status  Status         `valid:"enum,transitions=1>2"`
+	Source  string         `valid:"enum=Source,sensitive=custom"`
+	Remark  sql.NullString `valid:"gt=0"`
+	Items   []*Item        `valid:"required,dive"`
+	Item    *Item          `valid:"dive"`
+	Price   float64        `valid:"gt=0.5,in=1 2"` // want `in 不支持 float64 类型`
+	Skipped int            `valid:"-"`
+}
@@ -0,0 +1,262 @@
+package gvalid
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/24 14:00
+ * @Desc: 不使用 tag, 通过代码为结构体的字段注册规则, 用于其它包中的类型及运行时生成的规则
+ */
+
+// FieldRules 一个字段的规则, 由 Field 生成
+type FieldRules struct {
+	ptr   interface{}
+	rules RuleSet
+	label string
+}
+
+// Field 字段的规则, ptr 为字段的指针, 如 &u.Name
+func Field(ptr interface{}, rules ...Rule) *FieldRules {
+	return &FieldRules{ptr: ptr, rules: rules}
+}
+
+// Label 字段名称, 同 name tag, 用于错误信息
+func (fr *FieldRules) Label(name string) *FieldRules {
+	fr.label = name
+	return fr
+}
+
+// registeredField 注册的字段规则
+type registeredField struct {
+	rules RuleSet
+	label string
+}
+
+var (
+	typeRulesMu sync.RWMutex
+	typeRules   = make(map[reflect.Type]map[string]*registeredField)
+)
+
+// Rules 为 obj 的类型注册字段规则, obj 为结构体指针, 字段须为 obj 本身的字段, 如
+//
+//	u := &User{}
+//	err := gvalid.Rules(u, gvalid.Field(&u.Name, gvalid.Required(), gvalid.MaxLen(10)), gvalid.Field(&u.Mobile, gvalid.Mobile()))
+//
+// 规则与 tag 中的规则合并, 同名规则以注册的为准; 同一字段再次注册时替换之前注册的规则
+// 规则同 Compile 检查, 有错误时返回 CompileErrors, 不注册任何规则
+// 同 tag 一样作用于该类型的所有验证, 包括 dive, 但不影响 gvalid-gen 生成的代码
+func Rules(obj interface{}, fields ...*FieldRules) error {
+	vOf := reflect.ValueOf(obj)
+	if !vOf.IsValid() || !isStructPtr(vOf.Type()) || vOf.IsNil() {
+		return fmt.Errorf("%v 必须是 结构体指针", obj)
+	}
+	t := vOf.Type().Elem()
+	structName := t.Name()
+	if structName == "" {
+		structName = t.String()
+	}
+
+	var errs CompileErrors
+	registered := make(map[string]*registeredField, len(fields))
+	for _, fr := range fields {
+		f, err := fr.structField(t, vOf.Pointer())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", structName, err))
+			continue
+		}
+		ft := NewFieldType(f.Type)
+		for _, r := range fr.rules {
+			if err = CheckRule(r, ft); err != nil {
+				errs = append(errs, &RuleError{Struct: structName, Field: f.Name, Rule: r.Name, Msg: err.Error()})
+			}
+		}
+		registered[f.Name] = &registeredField{rules: fr.rules, label: fr.label}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	typeRulesMu.Lock()
+	defer typeRulesMu.Unlock()
+	if typeRules[t] == nil {
+		typeRules[t] = make(map[string]*registeredField)
+	}
+	for name, rf := range registered {
+		typeRules[t][name] = rf
+	}
+	return nil
+}
+
+// MustRules 同 Rules, 有错误时 panic
+func MustRules(obj interface{}, fields ...*FieldRules) {
+	if err := Rules(obj, fields...); err != nil {
+		panic(err)
+	}
+}
+
+// structField 按指针的偏移及类型查找字段
+func (fr *FieldRules) structField(t reflect.Type, base uintptr) (reflect.StructField, error) {
+	pOf := reflect.ValueOf(fr.ptr)
+	if !pOf.IsValid() || pOf.Kind() != reflect.Ptr || pOf.IsNil() {
+		return reflect.StructField{}, fmt.Errorf("%T 必须是 字段指针", fr.ptr)
+	}
+	if p := pOf.Pointer(); p >= base {
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.Offset == p-base && f.Type == pOf.Type().Elem() {
+				return f, nil
+			}
+		}
+	}
+	return reflect.StructField{}, fmt.Errorf("%s 不是 %s 的字段", pOf.Type(), t)
+}
+
+// registeredRules 字段注册的规则
+func registeredRules(t reflect.Type, name string) *registeredField {
+	typeRulesMu.RLock()
+	defer typeRulesMu.RUnlock()
+	return typeRules[t][name]
+}
+
+// mergeRules 合并 tag 及注册的规则, 同名规则以注册的为准
+func mergeRules(tag, registered RuleSet) RuleSet {
+	if len(registered) == 0 {
+		return tag
+	}
+	rules := make(RuleSet, 0, len(tag)+len(registered))
+	for _, r := range tag {
+		if !registered.Has(r.Name) {
+			rules = append(rules, r)
+		}
+	}
+	return append(rules, registered...)
+}
+
+// labeledField 没有 name tag 时使用注册的字段名称
+func labeledField(t reflect.Type, f reflect.StructField) reflect.StructField {
+	if _, ok := f.Tag.Lookup(defaultNameTag); ok {
+		return f
+	}
+	if rf := registeredRules(t, f.Name); rf != nil && rf.label != "" {
+		f.Tag = reflect.StructTag(strings.TrimSpace(fmt.Sprintf("%s %s:%q", f.Tag, defaultNameTag, rf.label)))
+	}
+	return f
+}
+
+// On 规则所属分组, 同 tag 中的 @create
+func (r Rule) On(groups ...string) Rule {
+	r.Groups = groups
+	return r
+}
+
+func newRule(name string, values ...string) Rule {
+	return Rule{Name: name, Params: newParams(name, values)}
+}
+
+func formatFloat(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func formatValues(values []interface{}) []string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = fmt.Sprint(v)
+	}
+	return s
+}
+
+// Required 同 required
+func Required() Rule { return newRule("required") }
+
+// Empty 同 empty
+func Empty() Rule { return newRule("empty") }
+
+// Gt 同 gt, 数字为取值, 字符串, slice, map 为长度
+func Gt(n float64) Rule { return newRule("gt", formatFloat(n)) }
+
+// Gte 同 gte
+func Gte(n float64) Rule { return newRule("gte", formatFloat(n)) }
+
+// Lt 同 lt
+func Lt(n float64) Rule { return newRule("lt", formatFloat(n)) }
+
+// Lte 同 lte
+func Lte(n float64) Rule { return newRule("lte", formatFloat(n)) }
+
+// MinLen 最小长度, 同 gte
+func MinLen(n int) Rule { return newRule("gte", strconv.Itoa(n)) }
+
+// MaxLen 最大长度, 同 lte
+func MaxLen(n int) Rule { return newRule("lte", strconv.Itoa(n)) }
+
+// Len 同 len
+func Len(n int) Rule { return newRule("len", strconv.Itoa(n)) }
+
+// Date 同 date, layout 如 2006-01-02
+func Date(layout string) Rule { return newRule("date", layout) }
+
+// StrGte 同 strGte, 字符串按字典序大于等于 s
+func StrGte(s string) Rule { return newRule("strGte", s) }
+
+// StrLte 同 strLte, 字符串按字典序小于等于 s
+func StrLte(s string) Rule { return newRule("strLte", s) }
+
+// In 同 in, 值可以包含空格和逗号
+func In(values ...interface{}) Rule { return newRule("in", formatValues(values)...) }
+
+// Sin 同 sin
+func Sin(values ...interface{}) Rule { return newRule("sin", formatValues(values)...) }
+
+// Dive 同 dive
+func Dive() Rule { return newRule(ruleName(diveFunc)) }
+
+// Regex 同 regex
+func Regex(pattern string) Rule { return newRule(toLowerCamel(RegexFunc), pattern) }
+
+// Email 同 email
+func Email() Rule { return newRule("email") }
+
+// Mobile 同 mobile
+func Mobile() Rule { return newRule("mobile") }
+
+// Base64 同 base64
+func Base64() Rule { return newRule("base64") }
+
+// Ip 同 ip
+func Ip() Rule { return newRule("ip") }
+
+// Url 同 url
+func Url() Rule { return newRule("url") }
+
+// IdCard 同 idCard
+func IdCard() Rule { return newRule("idCard") }
+
+// Numeric 同 numeric
+func Numeric() Rule { return newRule("numeric") }
+
+// Default 同 default
+func Default(v interface{}) Rule { return newRule("default", fmt.Sprint(v)) }
+
+// Distinct 同 distinct
+func Distinct() Rule { return newRule("distinct") }
+
+// TrimSpace 同 trimSpace
+func TrimSpace() Rule { return newRule("trimSpace") }
+
+// Immutable 同 immutable
+func Immutable() Rule { return newRule("immutable") }
+
+// Transitions 同 transitions, 如 Transitions("1>2", "2>3")
+func Transitions(pairs ...string) Rule { return newRule("transitions", strings.Join(pairs, " ")) }
+
+// EnumOf 同 enum, name 为 RegisterEnum 注册的名称, 省略时使用字段类型的 Enum 接口
+func EnumOf(name ...string) Rule { return newRule("enum", name...) }
+
+// Sensitive 同 sensitive, masker 为脱敏方式, 省略时全部脱敏
+func Sensitive(masker ...string) Rule { return newRule(SensitiveTag, masker...) }
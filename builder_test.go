@@ -0,0 +1,154 @@
+package gvalid
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/24 14:40
+ * @Desc:
+ */
+
+// builderAddress 模拟其它包中没有 tag 的类型
+type builderAddress struct {
+	City string
+}
+
+type builderUser struct {
+	Name    string
+	Mobile  string
+	Status  int `valid:"in=1 2" name:"状态"`
+	Tags    []string
+	Address []*builderAddress
+}
+
+type taggedUser struct {
+	Name    string   `valid:"required,lte=10" name:"姓名"`
+	Mobile  string   `valid:"mobile" name:"手机"`
+	Status  int      `valid:"in=1 2" name:"状态"`
+	Tags    []string `valid:"sin=a b @create" name:"标签"`
+	Address []*struct {
+		City string `valid:"required" name:"市"`
+	} `valid:"dive"`
+}
+
+func init() {
+	a := &builderAddress{}
+	MustRules(a, Field(&a.City, Required()).Label("市"))
+
+	u := &builderUser{}
+	MustRules(u,
+		Field(&u.Name, Required(), MaxLen(10)).Label("姓名"),
+		Field(&u.Mobile, Mobile()).Label("手机"),
+		Field(&u.Tags, Sin("a", "b").On("create")).Label("标签"),
+		Field(&u.Address, Dive()),
+	)
+}
+
+func messages(errs []*Error) []string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Path + " " + e.Rule + " " + e.String()
+	}
+	return msgs
+}
+
+func TestRules(t *testing.T) {
+	Convey("same errors as tags", t, func() {
+		built := &builderUser{Name: "abcdefghijk", Mobile: "123", Status: 3, Tags: []string{"c"}, Address: []*builderAddress{{}}}
+		tagged := &taggedUser{Name: built.Name, Mobile: built.Mobile, Status: built.Status, Tags: built.Tags, Address: []*struct {
+			City string `valid:"required" name:"市"`
+		}{{}}}
+
+		for _, groups := range [][]string{nil, {"create"}} {
+			v1, v2 := &Validation{Groups: groups}, &Validation{Groups: groups}
+			_, err := v1.Valid(built)
+			So(err, ShouldBeNil)
+			_, err = v2.Valid(tagged)
+			So(err, ShouldBeNil)
+			So(messages(v1.Errors), ShouldResemble, messages(v2.Errors))
+		}
+
+		v := &Validation{Groups: []string{"create"}}
+		_, _ = v.Valid(built)
+		So(messages(v.Errors), ShouldResemble, []string{
+			"Name lte 姓名 长度必须是小于等于 10",
+			"Mobile mobile 手机 格式错误",
+			"Status in 状态 必须是 1 2 其中一个",
+			"Tags sin 标签 必须是 a b 其中一个或多个",
+			"Address[0].City required 市 不能为空或零值",
+		})
+		So(v.Errors[0].Param, ShouldEqual, "10")
+		So(v.Errors[0].Code, ShouldEqual, "ERR_LTE")
+	})
+
+	Convey("registered rules override tag rules of the same name", t, func() {
+		u := &builderUser{}
+		So(Rules(u, Field(&u.Status, In(1, 2, 3))), ShouldBeNil)
+		defer MustRules(u, Field(&u.Status))
+
+		rules, err := fieldRules(reflect.TypeOf(u).Elem(), structField(u, "Status"))
+		So(err, ShouldBeNil)
+		r, _ := rules.Get("in")
+		So(r.Values(), ShouldResemble, []string{"1", "2", "3"})
+		So(len(rules), ShouldEqual, 1)
+
+		v := &Validation{}
+		ok, err := v.Valid(&builderUser{Name: "wei", Status: 3})
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+	})
+
+	Convey("schema and docs", t, func() {
+		ts, err := DescribeType(reflect.TypeOf(&builderUser{}))
+		So(err, ShouldBeNil)
+		So(ts.Fields[0].Label, ShouldEqual, "姓名")
+		So(ts.Fields[0].Rules.Has("required"), ShouldBeTrue)
+		So(ts.Fields[4].Dive, ShouldNotBeNil)
+		So(ts.Fields[4].Dive.Fields[0].Rules.Has("required"), ShouldBeTrue)
+		So(Compile(&builderUser{}), ShouldBeNil)
+	})
+
+	Convey("invalid rules", t, func() {
+		u, other := &builderUser{}, &builderAddress{}
+		err := Rules(u,
+			Field(&u.Status, Email(), Len(1)),
+			Field(&other.City, Required()),
+			Field(u.Name, Required()),
+			Field(&u.Name, Required()),
+		)
+		var errs CompileErrors
+		So(errors.As(err, &errs), ShouldBeTrue)
+		So(errorStrings(errs), ShouldResemble, []string{
+			"builderUser.Status: email: email 不支持 int 类型",
+			"builderUser.Status: len: len 不支持 int 类型",
+			"builderUser: *string 不是 gvalid.builderUser 的字段",
+			"builderUser: string 必须是 字段指针",
+		})
+		// 有错误时不注册任何规则
+		rules, _ := fieldRules(reflect.TypeOf(u).Elem(), structField(u, "Name"))
+		So(len(rules), ShouldEqual, 2)
+
+		So(Rules(builderUser{}), ShouldNotBeNil)
+		So(func() { MustRules(u, Field(&u.Status, Email())) }, ShouldPanic)
+	})
+}
+
+func errorStrings(errs []error) []string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return msgs
+}
+
+func structField(obj interface{}, name string) reflect.StructField {
+	f, _ := reflect.TypeOf(obj).Elem().FieldByName(name)
+	return f
+}
@@ -0,0 +1,287 @@
+package gvalid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 10:00
+ * @Desc: 启动时检查 tag, 未知的规则, 错误的参数, 不支持的类型
+ */
+
+// FieldType 规则检查用的字段类型, 不依赖 reflect.Value, 静态分析工具可自行构造
+type FieldType struct {
+	// Kind 去除指针后的类型
+	Kind reflect.Kind
+	// Type 去除指针后的类型名, 如 []int, sin 和 distinct 按类型名检查
+	Type string
+	// Elem slice, array, map 的元素类型, 去除指针
+	Elem *FieldType
+	// Enum 类型或其指针实现了 Enum 接口
+	Enum bool
+	// Custom 自定义类型 (RegisterCustomTypeFunc, driver.Valuer), 验证的是其实际值, 不检查类型
+	Custom bool
+}
+
+// NewFieldType 字段类型
+func NewFieldType(t reflect.Type) *FieldType {
+	return newFieldType(t, true)
+}
+
+func newFieldType(t reflect.Type, elem bool) *FieldType {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	ft := &FieldType{
+		Kind: t.Kind(),
+		Type: t.String(),
+		Enum: t.Implements(enumType) || reflect.PtrTo(t).Implements(enumType),
+	}
+	if _, ok := customTypeFuncs[t]; ok || t.Implements(valuerType) || reflect.PtrTo(t).Implements(valuerType) {
+		ft.Custom = true
+	}
+	if elem && (ft.Kind == reflect.Slice || ft.Kind == reflect.Array || ft.Kind == reflect.Map) {
+		ft.Elem = newFieldType(t.Elem(), false)
+	}
+	return ft
+}
+
+// RuleError 规则错误
+type RuleError struct {
+	Struct string
+	Field  string
+	Rule   string
+	// Column 规则在 tag 中的位置, 从 1 开始, Rules 注册的规则为 0
+	Column int
+	Msg    string
+}
+
+func (e *RuleError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("%s.%s: %s: %s", e.Struct, e.Field, e.Rule, e.Msg)
+	}
+	return fmt.Sprintf("%s.%s: %s 第 %d 列: %s", e.Struct, e.Field, e.Rule, e.Column, e.Msg)
+}
+
+// CompileErrors Compile 发现的所有错误, 元素为 *TagError 或 *RuleError
+type CompileErrors []error
+
+func (errs CompileErrors) Error() string {
+	s := make([]string, len(errs))
+	for i, err := range errs {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+// Compile 检查 types 及其 dive 的结构体的 tag, types 为结构体或结构体指针
+// 在服务启动时调用, 提前发现 tag 语法错误, 未知的规则, 错误的参数以及不支持的字段类型
+func Compile(types ...interface{}) error {
+	var errs CompileErrors
+	seen := make(map[reflect.Type]struct{})
+	for _, obj := range types {
+		t := reflect.TypeOf(obj)
+		if t == nil || !isStructOrStructPtr(t) {
+			errs = append(errs, fmt.Errorf("%v 必须是 结构体 或者 结构体指针", obj))
+			continue
+		}
+		compileType(t, seen, &errs)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// MustCompile 同 Compile, 有错误时 panic
+func MustCompile(types ...interface{}) {
+	if err := Compile(types...); err != nil {
+		panic(err)
+	}
+}
+
+func compileType(t reflect.Type, seen map[reflect.Type]struct{}, errs *CompileErrors) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if _, ok := seen[t]; ok {
+		return
+	}
+	seen[t] = struct{}{}
+
+	structName := t.Name()
+	if structName == "" {
+		structName = t.String()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		rules, err := fieldRules(t, f)
+		if err != nil {
+			*errs = append(*errs, err)
+			continue
+		}
+		ft := NewFieldType(f.Type)
+		for _, r := range rules {
+			if err = CheckRule(r, ft); err != nil {
+				*errs = append(*errs, &RuleError{Struct: structName, Field: f.Name, Rule: r.Name, Column: r.Column, Msg: err.Error()})
+				continue
+			}
+			if r.Name == ruleName(diveFunc) {
+				if et := diveElem(f.Type); isStruct(et) {
+					compileType(et, seen, errs)
+				}
+			}
+		}
+	}
+}
+
+// kindOf k 是否为 kinds 其中一个
+func kindOf(k reflect.Kind, kinds []reflect.Kind) bool {
+	for _, e := range kinds {
+		if e == k {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckRule 检查规则的参数以及是否支持字段类型, t 为 nil 时只检查参数
+func CheckRule(r Rule, t *FieldType) error {
+	if msg := checkRule(r, t); msg != "" {
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func checkRule(r Rule, t *FieldType) string {
+	// 自定义类型验证的是其实际值, 只检查参数
+	if t != nil && t.Custom {
+		t = nil
+	}
+	if r.Name == SensitiveTag {
+		if len(r.Params) > 0 {
+			if _, ok := maskerOf(r.Param()); !ok {
+				return fmt.Sprintf("未知的脱敏方式 %s", r.Param())
+			}
+		}
+		return ""
+	}
+	// 规则为 Validation 的 Rule* 方法, 没有 ruleSpec 的规则不检查参数及字段类型
+	fn := validFuncPrefix + toUpperCamel(r.Name)
+	if _, ok := validFuncMap[fn]; !ok || ruleName(fn) != r.Name {
+		return fmt.Sprintf("未知的规则 %s", r.Name)
+	}
+	spec := ruleSpecs[r.Name]
+	switch spec.params {
+	case noParams:
+		if msg := noParam(r); msg != "" {
+			return msg
+		}
+	case needParams:
+		if msg := oneParam(r); msg != "" {
+			return msg
+		}
+	}
+	if t != nil {
+		if msg := spec.kindError(r.Name, t.Kind, t.Type); msg != "" {
+			return msg
+		}
+	}
+	if spec.check != nil {
+		return spec.check(r, t)
+	}
+	return ""
+}
+
+// numberParams gt, gte, lt, lte 的参数, 浮点数字段为数字, 其它为整数
+func numberParams(r Rule, t *FieldType) string {
+	if t != nil && (t.Kind == reflect.Float32 || t.Kind == reflect.Float64) {
+		return floatParams(r)
+	}
+	return intParams(r)
+}
+
+// inParams in, default 的参数, 非字符串字段为整数
+func inParams(r Rule, t *FieldType) string {
+	if t != nil && t.Kind != reflect.String {
+		return intParams(r)
+	}
+	return ""
+}
+
+// sinParams sin 的参数, 非 []string 字段为整数
+func sinParams(r Rule, t *FieldType) string {
+	if t != nil && t.Type != "[]string" {
+		return intParams(r)
+	}
+	return ""
+}
+
+// transitionParams transitions 的参数, 如 1>2 2>3
+func transitionParams(r Rule, _ *FieldType) string {
+	for _, s := range strings.Fields(r.Param()) {
+		if len(strings.Split(s, ">")) != 2 {
+			return fmt.Sprintf("%s 的参数 %s 应为 旧值>新值", r.Name, s)
+		}
+	}
+	return ""
+}
+
+// enumRuleParams enum 的参数为 RegisterEnum 注册的名称, 没有参数时字段须实现 Enum 接口
+func enumRuleParams(r Rule, t *FieldType) string {
+	if len(r.Params) > 0 {
+		if _, ok := EnumValues(r.Param()); !ok {
+			return fmt.Sprintf("%s 未注册 %s", r.Name, r.Param())
+		}
+		return ""
+	}
+	if t != nil && !t.Enum && !(t.Elem != nil && t.Elem.Enum) {
+		return fmt.Sprintf("%s 没有实现 Enum 接口", t.Type)
+	}
+	return ""
+}
+
+// diveKinds dive 支持结构体及结构体的 slice
+func diveKinds(r Rule, t *FieldType) string {
+	if t != nil && t.Kind != reflect.Struct && !(t.Kind == reflect.Slice && t.Elem.Kind == reflect.Struct) {
+		return fmt.Sprintf("%s 不支持 %s 类型", r.Name, t.Type)
+	}
+	return ""
+}
+
+func noParam(r Rule) string {
+	if len(r.Params) > 0 {
+		return fmt.Sprintf("%s 不需要参数", r.Name)
+	}
+	return ""
+}
+
+func oneParam(r Rule) string {
+	if len(r.Params) == 0 {
+		return fmt.Sprintf("%s 缺少参数", r.Name)
+	}
+	return ""
+}
+
+func intParams(r Rule) string {
+	for _, p := range r.Params {
+		if p.Type != ParamInt {
+			return fmt.Sprintf("%s 的参数 %s 应为整数", r.Name, p.Value)
+		}
+	}
+	return ""
+}
+
+func floatParams(r Rule) string {
+	for _, p := range r.Params {
+		if p.Type != ParamInt && p.Type != ParamFloat {
+			return fmt.Sprintf("%s 的参数 %s 应为数字", r.Name, p.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package gvalid
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 10:40
+ * @Desc:
+ */
+
+type compileItem struct {
+	Price float64  `valid:"gt=a"`
+	Tags  []string `valid:"sin=1 2,len=x"`
+	Ids   []int    `valid:"sin=a"`
+}
+
+type compileOrder struct {
+	Name   string         `valid:"requird,gt=0.5"`
+	Age    int            `valid:"len=10,in=a b"`
+	Email  *string        `valid:"email,required=1"`
+	Rates  []float64      `valid:"sin=1 2,distinct"`
+	Status OrderStatus    `valid:"enum,transitions=1-2"`
+	Code   string         `valid:"enum,enum=NotExists,sensitive=foo"`
+	Remark sql.NullString `valid:"gt=0,lte=x"`
+	Items  []*compileItem `valid:"dive"`
+	Item   compileItem    `valid:"dive"`
+	Count  int            `valid:"dive"`
+	Bad    string         `valid:"in='a"`
+}
+
+func TestCompile(t *testing.T) {
+	Convey("test compile", t, func() {
+		So(Compile(&jsonGoods{}, Account{}, updateGoods{}, groupUser{}), ShouldBeNil)
+
+		err := Compile(compileOrder{})
+		So(err, ShouldNotBeNil)
+		var errs CompileErrors
+		So(errors.As(err, &errs), ShouldBeTrue)
+
+		msgs := make([]string, 0, len(errs))
+		for _, e := range errs {
+			msgs = append(msgs, e.Error())
+		}
+		So(msgs, ShouldResemble, []string{
+			"compileOrder.Name: requird 第 1 列: 未知的规则 requird",
+			"compileOrder.Name: gt 第 9 列: gt 的参数 0.5 应为整数",
+			"compileOrder.Age: len 第 1 列: len 不支持 int 类型",
+			"compileOrder.Age: in 第 8 列: in 的参数 a 应为整数",
+			"compileOrder.Email: required 第 7 列: required 不需要参数",
+			"compileOrder.Rates: sin 第 1 列: sin 不支持 []float64 类型",
+			"compileOrder.Rates: distinct 第 9 列: distinct 不支持 []float64 类型",
+			"compileOrder.Status: transitions 第 6 列: transitions 的参数 1-2 应为 旧值>新值",
+			"compileOrder.Code: enum 第 1 列: string 没有实现 Enum 接口",
+			"compileOrder.Code: enum 第 6 列: enum 未注册 NotExists",
+			"compileOrder.Code: sensitive 第 21 列: 未知的脱敏方式 foo",
+			"compileOrder.Remark: lte 第 6 列: lte 的参数 x 应为整数",
+			"compileItem.Price: gt 第 1 列: gt 的参数 a 应为数字",
+			"compileItem.Tags: len 第 9 列: len 的参数 x 应为整数",
+			"compileItem.Ids: sin 第 1 列: sin 的参数 a 应为整数",
+			"compileOrder.Count: dive 第 1 列: dive 不支持 int 类型",
+			`compileOrder.Bad: valid:"in='a" 第 4 列: 引号未闭合`,
+		})
+
+		So(Compile(1), ShouldNotBeNil)
+		So(func() { MustCompile(compileOrder{}) }, ShouldPanic)
+		So(func() { MustCompile(jsonGoods{}) }, ShouldNotPanic)
+	})
+
+	Convey("test rule specs", t, func() {
+		// 每个验证函数都有 ruleSpec, 规则名与 CheckRule 一致
+		for fn := range validFuncMap {
+			_, ok := ruleSpecs[ruleName(fn)]
+			So(ok, ShouldBeTrue)
+			if err := CheckRule(Rule{Name: ruleName(fn)}, nil); err != nil {
+				So(err.Error(), ShouldNotStartWith, "未知的规则")
+			}
+		}
+		So(CheckRule(Rule{Name: "Required"}, nil).Error(), ShouldEqual, "未知的规则 Required")
+
+		// 验证时同样按 ruleSpec 检查字段类型
+		type Goods struct {
+			Level int16  `valid:"gt=1" name:"等级"`
+			Code  string `valid:"distinct" name:"编码"`
+		}
+		v := &Validation{}
+		_, err := v.Valid(&Goods{Level: 5, Code: "a"})
+		So(err, ShouldBeNil)
+		So(messages(v.Errors), ShouldResemble, []string{
+			"Level gt 等级 验证方法 gt 不允许 int16",
+			"Code distinct 编码 验证方法 distinct 不允许 string",
+		})
+	})
+
+	Convey("test check rule", t, func() {
+		So(CheckRule(Rule{Name: "gt", Params: []Param{{"1", ParamInt}}}, nil), ShouldBeNil)
+		So(CheckRule(Rule{Name: "gt"}, nil), ShouldNotBeNil)
+		So(CheckRule(newRule("transitions", "1>2"), &FieldType{Kind: reflect.Uint16, Type: "uint16"}), ShouldBeNil)
+		So(CheckRule(newRule("transitions", "1>2"), &FieldType{Kind: reflect.Float64, Type: "float64"}).Error(), ShouldEqual, "transitions 不支持 float64 类型")
+		So(CheckRule(Rule{Name: "dive"}, &FieldType{Kind: reflect.Slice, Type: "[]int", Elem: &FieldType{Kind: reflect.Int, Type: "int"}}), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,618 @@
+package gvalid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/23 10:00
+ * @Desc: 导出前端表单验证用的规则描述, 及其 Go 参考实现
+ */
+
+// ClientDescriptor 前端验证用的规则描述, 由 ClientRules 生成, 序列化为 JSON 后由 JS 运行时解释执行
+// Evaluate 为其参考实现, 用于测试描述与服务端规则的行为是否一致
+type ClientDescriptor struct {
+	// Root 顶层结构体在 Types 中的名称
+	Root string `json:"root"`
+	// Groups 生成时的验证场景, 只包含未分组及属于 Groups 的规则
+	Groups []string `json:"groups,omitempty"`
+	// Types 结构体的字段, dive 的结构体按名称引用
+	Types map[string][]*ClientField `json:"types"`
+}
+
+// ClientField 字段的验证规则, 按顺序执行 Rules, dive 在其所在位置验证 Type 的字段
+type ClientField struct {
+	// Key JSON 中的字段名, 为空时 JSON 中没有该字段 (json:"-"), 始终为零值
+	Key string `json:"key,omitempty"`
+	// Field Go 字段名, 错误路径同 Error.Path, 如 List[0].ImgUrl; 匿名嵌入的结构体为空
+	Field string `json:"field,omitempty"`
+	Label string `json:"label,omitempty"`
+	// Kind string, int, float, float32, bool, array, map, object
+	// float32 比较前需转为单精度, 如 JS 的 Math.fround
+	Kind string `json:"kind"`
+	// Pointer 指针只有 null 为零值, 0, "" 等不是零值
+	Pointer bool `json:"pointer,omitempty"`
+	// Quoted json:",string", 数字以字符串传输
+	Quoted bool `json:"quoted,omitempty"`
+	// Type dive 的结构体在 Types 中的名称, Kind 为 array 时为元素的类型
+	Type  string        `json:"type,omitempty"`
+	Rules []*ClientRule `json:"rules"`
+}
+
+// ClientRule 规则
+type ClientRule struct {
+	Rule   string   `json:"rule"`
+	Params []string `json:"params,omitempty"`
+	// Pattern regex, email 等格式规则的正则, 同时兼容 RE2 和 JS 的 RegExp, regex 规则为 tag 中的原样
+	Pattern string `json:"pattern,omitempty"`
+	// Enum enum 规则的可选值, 为 RegisterEnum 注册的值
+	Enum []string `json:"enum,omitempty"`
+	// Message 未通过时的错误信息, 同 Error.Message, distinct 中的 %v 为字段值, 格式如 [1 2 1]
+	Message string `json:"message,omitempty"`
+}
+
+// ClientRules 生成 obj 的前端验证规则描述, obj 为结构体或结构体指针, groups 同 Validation.Groups
+// 只包含能在前端执行的规则: 自定义类型的字段, 未注册值的 enum, immutable, transitions,
+// ValidCustom 及 RegisterStructValidation 等只在服务端验证
+func ClientRules(obj interface{}, groups ...string) (*ClientDescriptor, error) {
+	ts, err := DescribeType(reflect.TypeOf(obj))
+	if err != nil {
+		return nil, err
+	}
+	b := &clientBuilder{
+		valid: &Validation{Groups: groups},
+		desc:  &ClientDescriptor{Groups: groups, Types: make(map[string][]*ClientField)},
+		names: make(map[reflect.Type]string),
+	}
+	b.desc.Root = b.typeName(ts)
+	return b.desc, nil
+}
+
+type clientBuilder struct {
+	// valid 用于判断规则是否属于验证场景
+	valid *Validation
+	desc  *ClientDescriptor
+	names map[reflect.Type]string
+}
+
+// typeName 结构体在 Types 中的名称, 第一次引用时生成其字段
+func (b *clientBuilder) typeName(ts *TypeSchema) string {
+	if name, ok := b.names[ts.Type]; ok {
+		return name
+	}
+	name := uniqueTypeName(ts, func(name string) bool {
+		_, ok := b.desc.Types[name]
+		return ok
+	})
+	b.names[ts.Type] = name
+	// 先占位, 递归的类型引用自身
+	b.desc.Types[name] = nil
+	b.desc.Types[name] = b.fields(ts)
+	return name
+}
+
+// fields 结构体的字段, 同 encoding/json 展开匿名结构体的字段, 省略没有规则的字段
+func (b *clientBuilder) fields(ts *TypeSchema) []*ClientField {
+	fields := []*ClientField{}
+	for _, fs := range ts.Fields {
+		if fs.Anonymous && fs.JSONName == "" {
+			if fs.Dive != nil && b.dive(fs.Rules) {
+				fields = append(fields, b.fields(fs.Dive)...)
+			}
+			continue
+		}
+		t := indirectType(fs.Type)
+		kind := clientKind(t)
+		if kind == "" || NewFieldType(t).Custom {
+			continue
+		}
+		f, _ := ts.Type.FieldByName(fs.Name)
+		cf := &ClientField{
+			Key:     fs.JSONName,
+			Field:   fs.Name,
+			Label:   fs.Label,
+			Kind:    kind,
+			Pointer: fs.Type.Kind() == reflect.Ptr,
+			Quoted:  jsonStringOption(f),
+			Rules:   []*ClientRule{},
+		}
+		if fs.Anonymous {
+			cf.Field = ""
+		}
+		for _, r := range fs.Rules {
+			if !b.valid.inGroups(r.Groups) {
+				continue
+			}
+			if r.Name == ruleName(diveFunc) {
+				if fs.Dive == nil {
+					continue
+				}
+				cf.Type = b.typeName(fs.Dive)
+			}
+			if cr := clientRule(r, t); cr != nil {
+				cf.Rules = append(cf.Rules, cr)
+			}
+		}
+		if len(cf.Rules) > 0 {
+			fields = append(fields, cf)
+		}
+	}
+	return fields
+}
+
+// dive 是否有属于验证场景的 dive
+func (b *clientBuilder) dive(rules RuleSet) bool {
+	for _, r := range rules {
+		if r.Name == ruleName(diveFunc) && b.valid.inGroups(r.Groups) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientKind JSON 中的值类型, []byte, time.Time 等以字符串传输的类型为空, 不导出
+func clientKind(t reflect.Type) string {
+	if t == timeType {
+		return ""
+	}
+	switch {
+	case t.Kind() == reflect.String:
+		return "string"
+	case t.Kind() == reflect.Bool:
+		return "bool"
+	case isIntKind(t.Kind()) || isUintKind(t.Kind()):
+		return "int"
+	case t.Kind() == reflect.Float32:
+		return "float32"
+	case t.Kind() == reflect.Float64:
+		return "float"
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return ""
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return "array"
+	case t.Kind() == reflect.Map:
+		return "map"
+	case t.Kind() == reflect.Struct:
+		return "object"
+	}
+	return ""
+}
+
+// boundMessages gt, gte, lt, lte, len 的错误信息, 依次为 int, float, string, slice
+var boundMessages = map[string][4]string{
+	"gt":  {ValidateValNotGtInt, ValidateValNotGtFloat, ValidateValNotGtString, ValidateValNotGtSlice},
+	"gte": {ValidateValNotGteInt, ValidateValNotGteFloat, ValidateValNotGteString, ValidateValNotGteSlice},
+	"lt":  {ValidateValNotLtInt, ValidateValNotLtFloat, ValidateValNotLtString, ValidateValNotLtSlice},
+	"lte": {ValidateValNotLteInt, ValidateValNotLteFloat, ValidateValNotLteString, ValidateValNotLteSlice},
+	"len": {"", "", ValidateValNotLenString, ValidateValNotLenSlice},
+}
+
+// formatPatterns 格式规则的正则
+var formatPatterns = map[string]string{
+	"email":   emailRegexString,
+	"mobile":  mobileRegexString,
+	"base64":  base64RegexString,
+	"ip":      ipRegexString,
+	"url":     urlRegexString,
+	"numeric": "^[0-9]*$",
+}
+
+// clientRule 规则的描述, 不能在前端执行的规则返回 nil
+func clientRule(r Rule, t reflect.Type) *ClientRule {
+	cr := &ClientRule{Rule: r.Name, Params: r.Values()}
+	switch r.Name {
+	case "required":
+		cr.Message = ValidateValCanNotEmpty
+	case "empty":
+		cr.Message = ValidateValMustEmpty
+	case "gt", "gte", "lt", "lte", "len":
+		msgs := boundMessages[r.Name]
+		switch {
+		case isFloatKind(t.Kind()):
+			f, _ := strconv.ParseFloat(r.Param(), 64)
+			cr.Message = fmt.Sprintf(msgs[1], f)
+		case isIntKind(t.Kind()) || isUintKind(t.Kind()):
+			n, _ := strconv.Atoi(r.Param())
+			cr.Message = fmt.Sprintf(msgs[0], n)
+		case t.Kind() == reflect.String:
+			n, _ := strconv.Atoi(r.Param())
+			cr.Message = fmt.Sprintf(msgs[2], n)
+		default:
+			n, _ := strconv.Atoi(r.Param())
+			cr.Message = fmt.Sprintf(msgs[3], n)
+		}
+	case "date":
+		cr.Message = fmt.Sprintf(ValidateValDateFormatErr, r.Param())
+	case "strGte":
+		cr.Message = fmt.Sprintf(ValidateValNotStrGte, r.Param())
+	case "strLte":
+		cr.Message = fmt.Sprintf(ValidateValNotStrLte, r.Param())
+	case "in":
+		cr.Message = fmt.Sprintf(ValidateValNotExists, quoteParams(cr.Params))
+	case "sin":
+		cr.Message = fmt.Sprintf(ValidateValNotExistsSlice, quoteParams(cr.Params))
+	case toLowerCamel(RegexFunc):
+		cr.Pattern, cr.Message = r.Param(), ValidateValNotFormatErr
+	case "email", "mobile", "base64", "ip", "url":
+		cr.Pattern, cr.Message = jsPattern(formatPatterns[r.Name]), ValidateValNotFormatErr
+	case "numeric":
+		cr.Pattern, cr.Message = formatPatterns[r.Name], ValidateValNotNumericErr
+	case "idCard":
+		cr.Message = ValidateValNotFormatErr
+	case "distinct":
+		cr.Message = strings.Replace(ValidateValMustDistinct, "%+v", "%v", 1)
+	case "enum":
+		// 未指定名称时验证 Enum 接口, 类型名已注册时以注册的值代替
+		name := r.Param()
+		if name == "" {
+			et := t
+			if et.Kind() == reflect.Slice || et.Kind() == reflect.Array {
+				et = indirectType(et.Elem())
+			}
+			name = et.Name()
+		}
+		values, ok := EnumValues(name)
+		if !ok {
+			return nil
+		}
+		for _, v := range values {
+			cr.Enum = append(cr.Enum, enumString([]interface{}{v}))
+		}
+		cr.Message = fmt.Sprintf(ValidateValNotExists, enumString(values))
+	case "default", "trimSpace", ruleName(diveFunc):
+	default:
+		return nil
+	}
+	return cr
+}
+
+var regexpHex = regexp.MustCompile(`\\x\{([0-9A-Fa-f]+)\}`)
+
+// jsPattern RE2 的 \x{HHHH} 转为 JS 也支持的写法, 非 ASCII 字符直接使用字符本身
+func jsPattern(pattern string) string {
+	return regexpHex.ReplaceAllStringFunc(pattern, func(s string) string {
+		n, _ := strconv.ParseUint(regexpHex.FindStringSubmatch(s)[1], 16, 32)
+		if n < 0x80 {
+			return fmt.Sprintf(`\x%02X`, n)
+		}
+		return string(rune(n))
+	})
+}
+
+// Evaluate 按描述验证 JSON 对象, 是 JS 运行时的参考实现, 返回的 Error 同服务端验证, 不包含 Kind 和 Value
+// JSON 与字段类型不符时返回 err, 同 json.Unmarshal
+func (d *ClientDescriptor) Evaluate(data []byte) ([]*Error, error) {
+	var obj map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	e := &clientEvaluator{desc: d}
+	if err := e.object(d.Root, obj, ""); err != nil {
+		return nil, err
+	}
+	return e.errs, nil
+}
+
+type clientEvaluator struct {
+	desc *ClientDescriptor
+	errs []*Error
+}
+
+// object 验证结构体 typ 的字段, path 为结构体的路径
+func (e *clientEvaluator) object(typ string, obj map[string]interface{}, path string) error {
+	fields, ok := e.desc.Types[typ]
+	if !ok {
+		return fmt.Errorf("未知的类型 %s", typ)
+	}
+	for _, f := range fields {
+		fieldPath := joinPath(path, f.Field)
+		var v interface{}
+		if f.Key != "" {
+			v = obj[f.Key]
+		}
+		v, err := f.value(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fieldPath, err)
+		}
+		for _, r := range f.Rules {
+			switch r.Rule {
+			case ruleName(diveFunc):
+				if err = e.dive(f, v, fieldPath); err != nil {
+					return err
+				}
+			case "default":
+				if f.zero(v) && len(r.Params) > 0 {
+					v = r.Params[0]
+					if f.Kind == "int" {
+						v = json.Number(r.Params[0])
+					}
+				}
+			case "trimSpace":
+				if s, ok := v.(string); ok {
+					v = strings.TrimSpace(s)
+				}
+			default:
+				if msg := f.check(r, v); msg != "" {
+					e.errs = append(e.errs, &Error{Field: f.Field, Name: f.Label, Message: msg, Path: fieldPath,
+						Rule: r.Rule, Param: strings.Join(r.Params, " "), Params: r.Params, Code: ErrorCode(r.Rule)})
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// dive 验证结构体或 slice 中的结构体, null 的结构体同服务端按零值验证, slice 中的 null 跳过
+func (e *clientEvaluator) dive(f *ClientField, v interface{}, path string) error {
+	if f.Type == "" {
+		return nil
+	}
+	switch v := v.(type) {
+	case nil:
+		if f.Kind == "object" {
+			return e.object(f.Type, map[string]interface{}{}, path)
+		}
+	case map[string]interface{}:
+		return e.object(f.Type, v, path)
+	case []interface{}:
+		for i, item := range v {
+			if item == nil {
+				continue
+			}
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s[%d]: "+ValidateValTypeMismatch, path, i, "object")
+			}
+			if err := e.object(f.Type, obj, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// value 检查 JSON 值的类型, json:",string" 的数字转为 json.Number
+func (f *ClientField) value(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if s, ok := v.(string); ok && f.Quoted {
+		v = json.Number(s)
+	}
+	ok := false
+	switch f.Kind {
+	case "string":
+		_, ok = v.(string)
+	case "bool":
+		_, ok = v.(bool)
+	case "int":
+		if n, isNum := v.(json.Number); isNum {
+			_, err := n.Int64()
+			ok = err == nil
+		}
+	case "float", "float32":
+		if n, isNum := v.(json.Number); isNum {
+			_, err := n.Float64()
+			ok = err == nil
+		}
+	case "array":
+		_, ok = v.([]interface{})
+	case "map", "object":
+		_, ok = v.(map[string]interface{})
+	}
+	if !ok {
+		return nil, fmt.Errorf(ValidateValTypeMismatch, f.Kind)
+	}
+	return v, nil
+}
+
+// zero 是否为解析到 Go 结构体后的零值, [] 和 {} 解析为非 nil 的 slice, map, 不是零值
+func (f *ClientField) zero(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	if f.Pointer {
+		return false
+	}
+	if obj, ok := v.(map[string]interface{}); ok && f.Kind == "object" {
+		return jsonZero(obj)
+	}
+	return jsonZero(v)
+}
+
+// jsonZero JSON 值是否为零值, 对象的所有字段均为零值时为零值
+func jsonZero(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case json.Number:
+		n, _ := v.Float64()
+		return n == 0
+	case map[string]interface{}:
+		for _, item := range v {
+			if !jsonZero(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// check 执行规则, 通过时返回空字符串; 除 required, empty 外的规则不验证零值
+func (f *ClientField) check(r *ClientRule, v interface{}) string {
+	zero := f.zero(v)
+	switch r.Rule {
+	case "required":
+		if zero {
+			return r.Message
+		}
+		return ""
+	case "empty":
+		if !zero {
+			return r.Message
+		}
+		return ""
+	}
+	if zero {
+		return ""
+	}
+
+	var param string
+	if len(r.Params) > 0 {
+		param = r.Params[0]
+	}
+	ok := true
+	switch r.Rule {
+	case "gt", "gte", "lt", "lte", "len":
+		ok = f.bound(r.Rule, param, v)
+	case "date":
+		_, err := time.ParseInLocation(param, v.(string), loc)
+		ok = err == nil
+	case "strGte":
+		ok = v.(string) >= param
+	case "strLte":
+		ok = v.(string) <= param
+	case "in":
+		ok = f.in(r.Params, v)
+	case "sin":
+		for _, item := range v.([]interface{}) {
+			if !f.in(r.Params, item) {
+				ok = false
+				break
+			}
+		}
+	case toLowerCamel(RegexFunc), "email", "mobile", "base64", "ip", "url", "numeric":
+		ok, _ = regexp.MatchString(r.Pattern, v.(string))
+	case "idCard":
+		ok = ValidIdCardCode(v.(string))
+	case "distinct":
+		items := v.([]interface{})
+		seen := make(map[string]struct{}, len(items))
+		s := make([]string, len(items))
+		for i, item := range items {
+			s[i] = fmt.Sprint(item)
+			if _, dup := seen[s[i]]; dup {
+				ok = false
+			}
+			seen[s[i]] = struct{}{}
+		}
+		if !ok {
+			return fmt.Sprintf(r.Message, "["+strings.Join(s, " ")+"]")
+		}
+	case "enum":
+		items, isSlice := v.([]interface{})
+		if !isSlice {
+			items = []interface{}{v}
+		}
+		for _, item := range items {
+			if item != nil && !containsString(r.Enum, fmt.Sprint(item)) {
+				ok = false
+				break
+			}
+		}
+	}
+	if ok {
+		return ""
+	}
+	return r.Message
+}
+
+// bound gt, gte, lt, lte, len, 数字比较值, 字符串比较字符数, slice 和 map 比较长度
+func (f *ClientField) bound(rule, param string, v interface{}) bool {
+	var c int
+	switch v := v.(type) {
+	case json.Number:
+		if f.Kind == "int" {
+			n, _ := v.Int64()
+			p, _ := strconv.ParseInt(param, 10, 64)
+			c = compareInt(n, p)
+			break
+		}
+		n, _ := v.Float64()
+		if f.Kind == "float32" {
+			n = float64(float32(n))
+		}
+		p, _ := strconv.ParseFloat(param, 64)
+		switch {
+		case n > p:
+			c = 1
+		case n < p:
+			c = -1
+		}
+	case string:
+		n, _ := strconv.ParseInt(param, 10, 64)
+		c = compareInt(int64(utf8.RuneCountInString(v)), n)
+	case []interface{}:
+		n, _ := strconv.ParseInt(param, 10, 64)
+		c = compareInt(int64(len(v)), n)
+	case map[string]interface{}:
+		n, _ := strconv.ParseInt(param, 10, 64)
+		c = compareInt(int64(len(v)), n)
+	}
+	switch rule {
+	case "gt":
+		return c > 0
+	case "gte":
+		return c >= 0
+	case "lt":
+		return c < 0
+	case "lte":
+		return c <= 0
+	}
+	return c == 0
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	}
+	return 0
+}
+
+// in 整数按值比较, 字符串按原样比较
+func (f *ClientField) in(params []string, v interface{}) bool {
+	switch v := v.(type) {
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return false
+		}
+		for _, p := range params {
+			if i, err := strconv.ParseInt(p, 10, 64); err == nil && i == n {
+				return true
+			}
+		}
+	case string:
+		return containsString(params, v)
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package gvalid
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/23 11:00
+ * @Desc:
+ */
+
+type clientAddress struct {
+	City string `json:"city" valid:"required,trimSpace,lte=4" name:"城市"`
+	Zip  string `json:"zip" valid:"len=6,numeric"`
+}
+
+type clientForm struct {
+	jsonSpu
+	Id       int64            `json:"id" valid:"empty" groups:"create"`
+	Title    string           `json:"title" valid:"trimSpace,required,gt=2,lte=5" name:"标题"`
+	Kind     string           `json:"kind" valid:"in='a b' c,default=c"`
+	Level    int              `json:"level,string" valid:"in=1 2"`
+	Price    float64          `json:"price" valid:"gt=0,lt=100.5"`
+	Rate     float32          `json:"rate" valid:"lte=0.1"`
+	Count    *int             `json:"count" valid:"required,gte=1"`
+	Email    string           `json:"email" valid:"email"`
+	Mobile   string           `json:"mobile" valid:"mobile,sensitive=mobile"`
+	IdCard   string           `json:"idCard" valid:"idCard"`
+	Date     string           `json:"date" valid:"date=2006-01-02,strGte=2020-01-01,strLte=2029-12-31"`
+	Code     string           `json:"code" valid:"regex=(/^[A-Z]+$/)"`
+	Tags     []string         `json:"tags" valid:"required,distinct,sin=new hot,lte=2"`
+	Ids      []int64          `json:"ids" valid:"distinct,sin=1 2 3"`
+	Status   OrderStatus      `json:"status" valid:"enum"`
+	Statuses []OrderStatus    `json:"statuses" valid:"enum=OrderStatus"`
+	Meta     map[string]int   `json:"meta" valid:"lte=1"`
+	Password string           `json:"password" valid:"gte=6@create"`
+	Address  *clientAddress   `json:"address" valid:"dive"`
+	Backups  []*clientAddress `json:"backups" valid:"dive"`
+	Remark   sql.NullString   `json:"remark" valid:"required"`
+	Version  int              `json:"version" valid:"immutable"`
+	Secret   string           `json:"-" valid:"required"`
+}
+
+// withoutValue Evaluate 的 Error 不包含 Kind 和 Value
+func withoutValue(errs []*Error) []*Error {
+	for _, e := range errs {
+		e.Kind, e.Value = "", nil
+	}
+	return errs
+}
+
+func TestClientRules(t *testing.T) {
+	Convey("test client rules descriptor", t, func() {
+		d, err := ClientRules(&jsonGoods{})
+		So(err, ShouldBeNil)
+		b, err := json.Marshal(d)
+		So(err, ShouldBeNil)
+		So(string(b), ShouldEqual, `{"root":"jsonGoods","types":{`+
+			`"jsonGoods":[`+
+			`{"key":"cate","field":"Cate","label":"商品分类","kind":"int","rules":[{"rule":"required","message":"不能为空或零值"},{"rule":"gt","params":["0"],"message":"必须是大于 0"}]},`+
+			`{"key":"name","field":"Name","label":"商品名称","kind":"string","rules":[{"rule":"required","message":"不能为空或零值"},{"rule":"lte","params":["10"],"message":"长度必须是小于等于 10"}]},`+
+			`{"key":"gallery","field":"Gallery","label":"图片","kind":"object","pointer":true,"type":"jsonImg","rules":[{"rule":"required","message":"不能为空或零值"},{"rule":"dive"}]},`+
+			`{"key":"list","field":"List","label":"图片列表","kind":"array","type":"jsonImg","rules":[{"rule":"dive"}]},`+
+			`{"key":"stock","field":"Stock","label":"库存","kind":"int","rules":[{"rule":"required","message":"不能为空或零值"},{"rule":"gte","params":["1"],"message":"必须是大于等于 1"}]}],`+
+			`"jsonImg":[{"key":"imgUrl","field":"ImgUrl","label":"图片地址","kind":"string","rules":[{"rule":"required","message":"不能为空或零值"}]}]}}`)
+
+		RegisterEnum("OrderStatus", OrderStatusPaid, OrderStatusShipped, OrderStatusDone)
+		defer delete(enums, "OrderStatus")
+
+		d, err = ClientRules(clientForm{}, "create")
+		So(err, ShouldBeNil)
+		So(d.Groups, ShouldResemble, []string{"create"})
+		fields := map[string]*ClientField{}
+		for _, f := range d.Types["clientForm"] {
+			fields[f.Field] = f
+		}
+		// 自定义类型, immutable 不导出
+		So(fields, ShouldNotContainKey, "Remark")
+		So(fields, ShouldNotContainKey, "Version")
+		So(fields["Secret"].Key, ShouldEqual, "")
+		So(fields["Level"].Quoted, ShouldBeTrue)
+		So(fields["Rate"].Kind, ShouldEqual, "float32")
+		So(fields["Price"].Rules[1].Message, ShouldEqual, "必须是小于 100.50")
+		So(fields["Status"].Rules[0].Enum, ShouldResemble, []string{"1", "2", "3"})
+		So(fields["Mobile"].Rules, ShouldHaveLength, 1)
+		So(fields["Email"].Rules[0].Pattern, ShouldNotContainSubstring, `\x{`)
+		So(fields["Password"].Rules[0].Rule, ShouldEqual, "gte")
+
+		d, err = ClientRules(clientForm{})
+		So(err, ShouldBeNil)
+		// 匿名结构体的字段展开, 没有规则的字段省略
+		So(d.Types["clientForm"][2].Field, ShouldEqual, "Gallery")
+		So(d.Types["clientForm"][4].Field, ShouldEqual, "Title")
+
+		_, err = ClientRules(1)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestClientEvaluate(t *testing.T) {
+	RegisterEnum("OrderStatus", OrderStatusPaid, OrderStatusShipped, OrderStatusDone)
+	defer delete(enums, "OrderStatus")
+
+	inputs := map[string]string{
+		"empty":   `{}`,
+		"null":    `{"count":null,"tags":null,"address":null,"gallery":null}`,
+		"valid":   `{"cate":1,"name":"衣服","gallery":{"imgUrl":"a.png"},"title":" 标题名 ","count":1,"tags":["new"],"level":"1"}`,
+		"zero":    `{"cate":0,"name":"","title":"   ","count":0,"tags":[],"ids":[],"meta":{},"address":{},"rate":0,"level":"0"}`,
+		"invalid": `{"cate":-1,"name":"12345678901","title":"  长标题长标题 ","kind":"d","level":"3","price":100.5,"rate":0.1,"count":0,"email":"a@","mobile":"1380013800","idCard":"110105194912310021","date":"2026/10/23","code":"abc","tags":["new","old","new"],"ids":[1,4,1],"status":9,"statuses":[1,null,9],"meta":{"a":1,"b":2},"password":"123","id":1}`,
+		"bounds":  `{"title":"12","price":0.5,"rate":0.2,"count":5,"tags":["new","hot"],"ids":[3],"status":2,"statuses":[3],"meta":{"a":1},"idCard":"11010519491231002X","date":"2026-10-23","code":"ABC","email":"a@b.com","mobile":"13800138000"}`,
+		"nested":  `{"gallery":{"imgUrl":""},"list":[{"imgUrl":""},null,{"imgUrl":"b.png"}],"address":{"city":" 上海市浦东 ","zip":"20000a"},"backups":[null,{"city":" ","zip":"200000"},{}]}`,
+	}
+
+	Convey("test client descriptor evaluates the same as Validation.Valid", t, func() {
+		for name, input := range inputs {
+			for _, groups := range [][]string{nil, {"create"}} {
+				Convey(fmt.Sprintf("%s %v", name, groups), func() {
+					d, err := ClientRules(&clientForm{}, groups...)
+					So(err, ShouldBeNil)
+					// 经过 JSON 序列化, 同前端拿到的描述
+					b, err := json.Marshal(d)
+					So(err, ShouldBeNil)
+					d = &ClientDescriptor{}
+					So(json.Unmarshal(b, d), ShouldBeNil)
+
+					form := &clientForm{}
+					So(json.Unmarshal([]byte(input), form), ShouldBeNil)
+					valid := &Validation{Groups: groups}
+					_, err = valid.Valid(form)
+					So(err, ShouldBeNil)
+					// Remark 为自定义类型, 只在服务端验证
+					var expected []*Error
+					for _, e := range valid.Errors {
+						if e.Field != "Remark" {
+							expected = append(expected, e)
+						}
+					}
+
+					actual, err := d.Evaluate([]byte(input))
+					So(err, ShouldBeNil)
+					So(actual, ShouldResemble, withoutValue(expected))
+				})
+			}
+		}
+	})
+
+	Convey("test client evaluate type errors", t, func() {
+		d, err := ClientRules(&clientForm{})
+		So(err, ShouldBeNil)
+		_, err = d.Evaluate([]byte(`{"title":1}`))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "Title: 类型错误, 应为 string")
+		_, err = d.Evaluate([]byte(`{"cate":1.5}`))
+		So(err, ShouldNotBeNil)
+		_, err = d.Evaluate([]byte(`{"backups":[1]}`))
+		So(err, ShouldNotBeNil)
+		_, err = d.Evaluate([]byte(`[]`))
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"html"
+	"reflect"
+	"strings"
+
+	"github.com/booldesign/gvalid"
+	"github.com/booldesign/gvalid/internal/gotypes"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/23 16:30
+ * @Desc: 静态分析包中的结构体生成参数文档, 与 gvalid.ParamDocs 的结果一致
+ */
+
+// Render 为 dir 中的 types 生成 format 格式 (md 或 html) 的参数文档
+// types 为空时为所有带有 valid tag 的导出结构体, 按声明顺序
+func Render(dir string, typeNames []string, format string, groups []string) ([]byte, error) {
+	if format != "md" && format != "html" {
+		return nil, fmt.Errorf("不支持的格式 %s, 应为 md 或 html", format)
+	}
+	p, err := gotypes.LoadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	d := &documenter{fset: p.Fset, groups: groups}
+	docs := typeDocs(p.Files)
+
+	var objs []*types.TypeName
+	if len(typeNames) == 0 {
+		for _, f := range p.Files {
+			for _, decl := range f.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					obj, _ := p.Types.Scope().Lookup(spec.(*ast.TypeSpec).Name.Name).(*types.TypeName)
+					if obj != nil && obj.Exported() && hasRules(obj.Type()) {
+						objs = append(objs, obj)
+					}
+				}
+			}
+		}
+	}
+	for _, name := range typeNames {
+		obj, ok := p.Types.Scope().Lookup(strings.TrimSpace(name)).(*types.TypeName)
+		if !ok || !gotypes.IsStruct(obj.Type()) {
+			return nil, fmt.Errorf("%s 中没有结构体 %s", p.Types.Name(), name)
+		}
+		objs = append(objs, obj)
+	}
+
+	var b bytes.Buffer
+	for i, obj := range objs {
+		var rows []*gvalid.ParamDoc
+		d.visiting = map[types.Type]bool{obj.Type(): true}
+		d.fields(obj.Type().Underlying().(*types.Struct), obj.Name(), "", &rows)
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		doc := strings.TrimSpace(docs[obj.Name()])
+		if format == "md" {
+			fmt.Fprintf(&b, "## %s\n\n", obj.Name())
+			if doc != "" {
+				b.WriteString(doc + "\n\n")
+			}
+			b.WriteString(gvalid.MarkdownTable(rows))
+		} else {
+			fmt.Fprintf(&b, "<h2>%s</h2>\n", obj.Name())
+			if doc != "" {
+				fmt.Fprintf(&b, "<p>%s</p>\n", html.EscapeString(doc))
+			}
+			b.WriteString(gvalid.HTMLTable(rows))
+		}
+	}
+	if len(d.errs) > 0 {
+		return nil, errors.New(strings.Join(d.errs, "\n"))
+	}
+	return b.Bytes(), nil
+}
+
+// typeDocs 类型名 => 类型的注释
+func typeDocs(files []*ast.File) map[string]string {
+	docs := make(map[string]string)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Doc != nil {
+					docs[ts.Name.Name] = ts.Doc.Text()
+				} else if gd.Doc != nil && len(gd.Specs) == 1 {
+					docs[ts.Name.Name] = gd.Doc.Text()
+				}
+			}
+		}
+	}
+	return docs
+}
+
+// jsonString 有 json:",string" 选项, 数字和布尔值以字符串传输
+func jsonString(tag reflect.StructTag) bool {
+	for _, opt := range strings.Split(tag.Get("json"), ",")[1:] {
+		if opt == "string" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRules 结构体有 valid tag 的字段
+func hasRules(t types.Type) bool {
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		if vt := reflect.StructTag(st.Tag(i)).Get("valid"); vt != "" && vt != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+type documenter struct {
+	fset   *token.FileSet
+	groups []string
+	// visiting 正在展开的结构体, 递归的类型不再展开
+	visiting map[types.Type]bool
+	errs     []string
+}
+
+func (d *documenter) errorf(pos token.Pos, format string, a ...interface{}) {
+	d.errs = append(d.errs, fmt.Sprintf("%s: %s", d.fset.Position(pos), fmt.Sprintf(format, a...)))
+}
+
+// fields 同 gvalid.ParamDocs, 未导出的非匿名字段不在 JSON 中
+func (d *documenter) fields(st *types.Struct, owner, prefix string, rows *[]*gvalid.ParamDoc) {
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		if !v.Exported() && !v.Anonymous() {
+			continue
+		}
+		tag := reflect.StructTag(st.Tag(i))
+		all, err := gotypes.FieldRules(v, tag)
+		if err != nil {
+			var te *gvalid.TagError
+			if errors.As(err, &te) {
+				te.Struct, te.Field = owner, v.Name()
+			}
+			d.errorf(v.Pos(), "%v", err)
+			continue
+		}
+		rules := all.ForGroups(d.groups...)
+		dive := rules.Has("dive")
+		name := gotypes.JSONName(v, tag)
+		if name == "" {
+			if v.Anonymous() && dive {
+				d.dive(v.Type(), prefix, rows)
+			}
+			continue
+		}
+		ft := gotypes.FieldType(v.Type())
+		row := &gvalid.ParamDoc{
+			Name:        prefix + name,
+			Type:        gvalid.DocType(ft),
+			Required:    rules.Has("required"),
+			Constraints: gvalid.DocConstraints(rules, ft),
+			Description: tag.Get("name"),
+		}
+		if jsonString(tag) {
+			row.Type = "string"
+		}
+		*rows = append(*rows, row)
+		if dive {
+			d.dive(v.Type(), row.Name, rows)
+		}
+	}
+}
+
+// dive 展开 t 或其元素的结构体, 同 Validation 的 dive, 每层 slice 在 prefix 后加一个 []
+func (d *documenter) dive(t types.Type, prefix string, rows *[]*gvalid.ParamDoc) {
+	for {
+		t = gotypes.Deref(t)
+		if s, ok := t.Underlying().(*types.Slice); ok {
+			t = s.Elem()
+		} else if a, ok := t.Underlying().(*types.Array); ok {
+			t = a.Elem()
+		} else {
+			break
+		}
+		prefix += "[]"
+	}
+	if prefix != "" {
+		prefix += "."
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok || d.visiting[t] {
+		return
+	}
+	owner := types.TypeString(t, func(*types.Package) string { return "" })
+	d.visiting[t] = true
+	d.fields(st, owner, prefix, rows)
+	delete(d.visiting, t)
+}
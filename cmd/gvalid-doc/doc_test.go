@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/booldesign/gvalid"
+	"github.com/booldesign/gvalid/cmd/gvalid-doc/testdata/api"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/23 17:30
+ * @Desc:
+ */
+
+func TestRender(t *testing.T) {
+	Convey("test rendered doc is up to date", t, func() {
+		doc, err := Render("testdata/api", nil, "md", nil)
+		So(err, ShouldBeNil)
+		golden, err := os.ReadFile("testdata/api.md")
+		So(err, ShouldBeNil)
+		// 不一致时执行 go run . testdata/api > testdata/api.md
+		So(string(doc), ShouldEqual, string(golden))
+	})
+
+	Convey("test static docs are the same as gvalid.ParamDocs", t, func() {
+		objs := map[string]interface{}{"Page": api.Page{}, "Image": &api.Image{}, "CreateGoods": api.CreateGoods{}}
+		for name, obj := range objs {
+			for _, groups := range [][]string{nil, {"create"}} {
+				for _, format := range []string{"md", "html"} {
+					doc, err := Render("testdata/api", []string{name}, format, groups)
+					So(err, ShouldBeNil)
+					docs, err := gvalid.ParamDocs(obj, groups...)
+					So(err, ShouldBeNil)
+					if format == "md" {
+						So(string(doc), ShouldEndWith, "\n\n"+gvalid.MarkdownTable(docs))
+					} else {
+						So(string(doc), ShouldEndWith, "</p>\n"+gvalid.HTMLTable(docs))
+					}
+				}
+			}
+		}
+	})
+
+	Convey("test render errors", t, func() {
+		_, err := Render("testdata/api", []string{"NotExists"}, "md", nil)
+		So(err, ShouldNotBeNil)
+		_, err = Render("testdata/api", nil, "pdf", nil)
+		So(err, ShouldNotBeNil)
+		_, err = Render("../gvalid-gen/testdata/bad", []string{"Bad"}, "md", nil)
+		So(err, ShouldNotBeNil)
+		So(strings.Split(err.Error(), "\n")[0], ShouldEndWith, `Bad.Quoted: valid:"in='a" 第 4 列: 引号未闭合`)
+	})
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/23 16:30
+ * @Desc: 根据 valid tag 生成 Markdown 或 HTML 的接口参数文档
+ */
+
+var (
+	typeNames = flag.String("type", "", "逗号分隔的结构体类型名, 默认为所有带有 valid tag 的导出结构体")
+	format    = flag.String("format", "md", "输出格式, md 或 html")
+	groups    = flag.String("groups", "", "逗号分隔的验证场景, 只包含该场景下执行的规则")
+	output    = flag.String("output", "", "输出文件名, 默认为标准输出")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of gvalid-doc:\n")
+	fmt.Fprintf(os.Stderr, "\tgvalid-doc [-type T] [-format md|html] [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+// split 逗号分隔的非空值
+func split(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gvalid-doc: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	doc, err := Render(dir, split(*typeNames), *format, split(*groups))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(doc)
+	} else {
+		err = os.WriteFile(*output, doc, 0644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Page 分页参数
+type Page struct {
+	Page int `json:"page" valid:"gte=1" name:"页码"`
+	Size int `json:"size" valid:"gte=1,lte=100" name:"每页数量"`
+}
+
+// Image 图片
+type Image struct {
+	Url  string `json:"url" valid:"required,url" name:"图片地址"`
+	Alt  string `json:"alt" valid:"lte=20" name:"说明|替代文本"`
+	Next *Image `json:"next" valid:"dive"`
+}
+
+// CreateGoods 创建商品
+// 名称, 分类必填
+type CreateGoods struct {
+	Page
+	Id       int64          `json:"id" valid:"empty" groups:"create" name:"编号"`
+	Name     string         `json:"name" valid:"required,trimSpace,lte=10" name:"商品名称"`
+	Cate     int            `json:"cate,string" valid:"required,in=1 2" name:"分类"`
+	Price    float64        `json:"price" valid:"gt=0,lt=10000" name:"价格"`
+	Tags     []string       `json:"tags" valid:"distinct,sin=new hot"`
+	Code     string         `json:"code" valid:"regex=(/^[A-Z_]+$/)"`
+	Cover    *Image         `json:"cover" valid:"required,dive" name:"封面"`
+	Gallery  [][]*Image     `json:"gallery" valid:"lte=9,dive"`
+	Remark   sql.NullString `json:"remark" valid:"gte=2@create"`
+	OnSale   time.Time      `json:"onSale"`
+	Internal string         `json:"-" valid:"required"`
+	secret   string         `valid:"required"`
+}
+
+// untagged 没有 valid tag 的结构体不生成文档
+type Untagged struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,651 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/booldesign/gvalid"
+	"github.com/booldesign/gvalid/internal/gotypes"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 17:00
+ * @Desc: 生成验证代码, 与 Validation.Valid 的结果一致
+ */
+
+// nullTypes 内置 RegisterCustomTypeFunc 的 database/sql 类型, 验证其第一个字段
+var nullTypes = map[string]bool{
+	"NullString": true, "NullInt64": true, "NullInt32": true, "NullFloat64": true, "NullBool": true, "NullTime": true,
+}
+
+// stringFuncs 只验证字符串格式的规则对应的函数
+var stringFuncs = map[string]string{
+	"email":  "ValidEmail",
+	"mobile": "ValidMobile",
+	"base64": "ValidBase64",
+	"ip":     "ValidIp",
+	"url":    "ValidUrl",
+	"idCard": "ValidIdCardCode",
+}
+
+// compareOps gt, gte, lt, lte, len 通过时的比较
+var compareOps = map[string]string{"gt": ">", "gte": ">=", "lt": "<", "lte": "<=", "len": "=="}
+
+// Generate 为 dir 中的 types 及其 dive 的同包结构体生成验证代码
+func Generate(dir string, typeNames []string) ([]byte, error) {
+	p, err := gotypes.LoadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	pkg := p.Types
+	g := &generator{fset: p.Fset, pkg: pkg, imports: make(map[string]string), regexps: make(map[string]string), done: make(map[*types.TypeName]bool)}
+	for _, name := range typeNames {
+		obj, ok := pkg.Scope().Lookup(strings.TrimSpace(name)).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("%s 中没有类型 %s", pkg.Name(), name)
+		}
+		g.enqueue(obj)
+	}
+	for len(g.todo) > 0 {
+		obj := g.todo[0]
+		g.todo = g.todo[1:]
+		g.genType(obj)
+	}
+	if len(g.errs) > 0 {
+		return nil, errors.New(strings.Join(g.errs, "\n"))
+	}
+	return g.source()
+}
+
+type generator struct {
+	fset *token.FileSet
+	pkg  *types.Package
+	// imports 导入的包, path => name
+	imports map[string]string
+	// regexps 正则表达式 => 变量名
+	regexps map[string]string
+	todo    []*types.TypeName
+	done    map[*types.TypeName]bool
+	buf     bytes.Buffer
+	errs    []string
+}
+
+func (g *generator) enqueue(obj *types.TypeName) {
+	if !g.done[obj] {
+		g.done[obj] = true
+		g.todo = append(g.todo, obj)
+	}
+}
+
+func (g *generator) printf(format string, a ...interface{}) {
+	fmt.Fprintf(&g.buf, format, a...)
+}
+
+func (g *generator) errorf(pos token.Pos, format string, a ...interface{}) {
+	g.errs = append(g.errs, fmt.Sprintf("%s: %s", g.fset.Position(pos), fmt.Sprintf(format, a...)))
+}
+
+// qualifier 类型名中的包名, 并记录导入
+func (g *generator) qualifier(p *types.Package) string {
+	if p == g.pkg {
+		return ""
+	}
+	g.imports[p.Path()] = p.Name()
+	return p.Name()
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+// use 导入标准库或 gvalid, 返回包名
+func (g *generator) use(path string) string {
+	name := path[strings.LastIndex(path, "/")+1:]
+	g.imports[path] = name
+	return name
+}
+
+// regexp 正则对应的包级变量名
+func (g *generator) regexp(pattern string) string {
+	name, ok := g.regexps[pattern]
+	if !ok {
+		name = "gvalidRegexp" + strconv.Itoa(len(g.regexps))
+		g.regexps[pattern] = name
+	}
+	return name
+}
+
+func (g *generator) source() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s\n\npackage %s\n\n", gotypes.GeneratedHeader, g.pkg.Name())
+
+	if len(g.regexps) > 0 {
+		g.use("regexp")
+	}
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	b.WriteString("import (\n")
+	// 标准库在前, 其它包在后
+	for _, std := range []bool{true, false} {
+		if !std {
+			b.WriteString("\n")
+		}
+		for _, path := range paths {
+			if isStd(path) == std {
+				fmt.Fprintf(&b, "\t%q\n", path)
+			}
+		}
+	}
+	b.WriteString(")\n\n")
+
+	patterns := make([]string, 0, len(g.regexps))
+	for pattern := range g.regexps {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool { return g.regexps[patterns[i]] < g.regexps[patterns[j]] })
+	for _, pattern := range patterns {
+		fmt.Fprintf(&b, "var %s = regexp.MustCompile(%q)\n\n", g.regexps[pattern], pattern)
+	}
+
+	b.Write(g.buf.Bytes())
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("格式化生成的代码: %v\n%s", err, b.Bytes())
+	}
+	return src, nil
+}
+
+func isStd(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+func (g *generator) genType(obj *types.TypeName) {
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		g.errorf(obj.Pos(), "%s 不是结构体", obj.Name())
+		return
+	}
+	gv := g.use("github.com/booldesign/gvalid")
+	name := obj.Name()
+	g.printf("// Validate 验证, 与 gvalid.Validation.Valid 的结果一致, 错误为 %s.Errors\n", gv)
+	g.printf("func (x *%s) Validate() error {\n\treturn x.ValidateGroups()\n}\n\n", name)
+	g.printf("// ValidateGroups 验证场景 groups 下验证, 同 gvalid.Validation.Groups\n")
+	g.printf("func (x *%s) ValidateGroups(groups ...string) error {\n", name)
+	g.printf("\tg := &%s.Generated{Groups: groups}\n", gv)
+	g.printf("\tif err := x.gvalidValidate(g, nil); err != nil {\n\t\treturn err\n\t}\n")
+	g.printf("\treturn g.Err()\n}\n\n")
+
+	g.printf("func (x *%s) gvalidValidate(g *%s.Generated, embeddedIn interface{}) error {\n", name, gv)
+	for i := 0; i < st.NumFields(); i++ {
+		g.genField(obj, st.Field(i), reflect.StructTag(st.Tag(i)))
+	}
+	g.printf("\treturn g.Struct(x, embeddedIn)\n}\n\n")
+}
+
+// field 生成代码时的字段
+type field struct {
+	v     *types.Var
+	owner string
+	// label name tag, masker tag 中 sensitive 的脱敏方式
+	label, masker string
+	// acc 字段的表达式, typ 字段类型
+	acc string
+	typ types.Type
+	// ptr 字段为指针, elem 去除指针后的类型
+	ptr  bool
+	elem types.Type
+	// null database/sql 的 Null* 类型的值字段, 此时验证局部变量 cv
+	null *types.Var
+}
+
+// val 验证的值, 指针取其指向的值
+func (f *field) val() string {
+	switch {
+	case f.null != nil:
+		return "cv"
+	case f.ptr:
+		return "*" + f.acc
+	}
+	return f.acc
+}
+
+// operand 用于方法调用和下标的值, 指针加括号
+func (f *field) operand() string {
+	if f.ptr && f.null == nil {
+		return "(" + f.val() + ")"
+	}
+	return f.val()
+}
+
+// valType 验证的值的类型
+func (f *field) valType() types.Type {
+	if f.null != nil {
+		return f.null.Type()
+	}
+	return f.elem
+}
+
+// errValue Error.Value, 未导出的字段为 nil, 同 reflect 的 CanInterface
+func (f *field) errValue() string {
+	if !f.v.Exported() {
+		return "nil"
+	}
+	return f.val()
+}
+
+func (f *field) kind() string {
+	return gotypes.FieldType(f.valType()).Kind.String()
+}
+
+func (g *generator) genField(owner *types.TypeName, v *types.Var, tag reflect.StructTag) {
+	pos := v.Pos()
+	rules, err := gotypes.FieldRules(v, tag)
+	if err != nil {
+		var te *gvalid.TagError
+		if errors.As(err, &te) {
+			g.errorf(pos, "%s.%s: %s", owner.Name(), v.Name(), te.Msg)
+		} else {
+			g.errorf(pos, "%s.%s: %v", owner.Name(), v.Name(), err)
+		}
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	f := &field{v: v, owner: owner.Name(), label: tag.Get("name"), acc: "x." + v.Name(), typ: v.Type(), elem: v.Type()}
+	if p, ok := f.typ.(*types.Pointer); ok {
+		f.ptr, f.elem = true, p.Elem()
+		if _, ok = f.elem.(*types.Pointer); ok {
+			g.errorf(pos, "%s.%s: 不支持多级指针", f.owner, v.Name())
+			return
+		}
+	}
+	if f.elem == types.Typ[types.Invalid] {
+		g.errorf(pos, "%s.%s: 无法确定字段类型", f.owner, v.Name())
+		return
+	}
+	ft := gotypes.FieldType(f.elem)
+	if ft.Custom {
+		if f.null = nullField(f.elem); f.null == nil || f.ptr {
+			g.errorf(pos, "%s.%s: 不支持自定义类型 %s, 只支持 database/sql 的 Null* 类型", f.owner, v.Name(), ft.Type)
+			return
+		}
+		ft = gotypes.FieldType(f.null.Type())
+	}
+
+	for _, r := range rules {
+		if r.Name == gvalid.SensitiveTag {
+			if f.masker = r.Param(); f.masker == "" {
+				f.masker = gvalid.MaskFull
+			}
+		}
+	}
+	// 运行时注册的参数不检查
+	check := func(r gvalid.Rule) bool {
+		if (r.Name == "enum" || r.Name == gvalid.SensitiveTag) && len(r.Params) > 0 {
+			return true
+		}
+		t := ft
+		if r.Name == "dive" {
+			t = gotypes.FieldType(f.typ)
+		}
+		if err := gvalid.CheckRule(r, t); err != nil {
+			g.errorf(pos, "%s.%s: %s 第 %d 列: %v", f.owner, v.Name(), r.Name, r.Column, err)
+			return false
+		}
+		return true
+	}
+
+	if f.null != nil {
+		g.printf("\t{\n\tcv := %s.%s\n", f.acc, f.null.Name())
+	}
+	for _, r := range rules {
+		if !check(r) {
+			continue
+		}
+		var code string
+		if code, err = g.rule(f, r); err != nil {
+			g.errorf(pos, "%s.%s: %s 第 %d 列: %v", f.owner, v.Name(), r.Name, r.Column, err)
+			continue
+		}
+		if code == "" {
+			continue
+		}
+		if len(r.Groups) > 0 {
+			g.printf("\tif g.InGroups(%s) {\n%s\t}\n", quoteList(r.Groups), code)
+		} else {
+			g.printf("%s", code)
+		}
+	}
+	if f.null != nil {
+		g.printf("\t}\n")
+	}
+}
+
+// nullField database/sql 的 Null* 类型的值字段, 如 NullString.String
+func nullField(t types.Type) *types.Var {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "database/sql" || !nullTypes[named.Obj().Name()] {
+		return nil
+	}
+	return named.Underlying().(*types.Struct).Field(0)
+}
+
+func quoteList(values []string) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Quote(v)
+	}
+	return strings.Join(s, ", ")
+}
+
+// zero 值为零值的条件, 及其否定, 同 reflect.Value.IsZero
+func (g *generator) zero(expr string, t types.Type) (eq, ne string, err error) {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return expr + ` == ""`, expr + ` != ""`, nil
+		case u.Info()&types.IsBoolean != 0:
+			return "!" + expr, expr, nil
+		case u.Info()&types.IsNumeric != 0:
+			return expr + " == 0", expr + " != 0", nil
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return expr + " == nil", expr + " != nil", nil
+	case *types.Struct, *types.Array:
+		if types.Comparable(t) {
+			lit := "(" + g.typeString(t) + "{})"
+			return expr + " == " + lit, expr + " != " + lit, nil
+		}
+	}
+	return "", "", fmt.Errorf("不支持 %s 类型", g.typeString(t))
+}
+
+// fieldZero 字段为零值的条件, 及其否定, 指针为 nil, Null* 类型为 !Valid 或值为零值
+func (g *generator) fieldZero(f *field) (eq, ne string, err error) {
+	if f.null == nil {
+		return g.zero(f.acc, f.typ)
+	}
+	if eq, ne, err = g.zero("cv", f.null.Type()); err != nil {
+		return
+	}
+	return "!" + f.acc + ".Valid || " + eq, f.acc + ".Valid && " + ne, nil
+}
+
+// conv 将 expr 转为基础类型 basic, 类型相同时不转换
+func (g *generator) conv(basic string, expr string, t types.Type) string {
+	if b, ok := t.(*types.Basic); ok && b.Name() == basic {
+		return expr
+	}
+	return basic + "(" + expr + ")"
+}
+
+// fail 调用 Generated.Fail 的代码
+func (g *generator) fail(f *field, r gvalid.Rule, value, kind, msg string) string {
+	params := ""
+	if values := r.Values(); len(values) > 0 {
+		params = fmt.Sprintf("Params: %#v, ", values)
+	}
+	return fmt.Sprintf("g.Fail(&%s.Error{Field: %q, Name: %q, Message: %s, Rule: %q, Param: %q, %sKind: %q, Value: %s}, %q)\n",
+		g.use("github.com/booldesign/gvalid"), f.v.Name(), f.label, msg, r.Name, r.Param(), params, kind, value, f.masker)
+}
+
+// failValue 值未通过验证时调用 Generated.Fail 的代码
+func (g *generator) failValue(f *field, r gvalid.Rule, msg string) string {
+	return g.fail(f, r, f.errValue(), f.kind(), msg)
+}
+
+func (g *generator) sprintf(format string, a ...string) string {
+	gv := g.use("github.com/booldesign/gvalid")
+	return fmt.Sprintf("%s.Sprintf(%s.%s, %s)", g.use("fmt"), gv, format, strings.Join(a, ", "))
+}
+
+// rule 规则对应的代码
+func (g *generator) rule(f *field, r gvalid.Rule) (string, error) {
+	if r.Name == "dive" {
+		return g.dive(f)
+	}
+	eq, ne, err := g.fieldZero(f)
+	if err != nil {
+		return "", err
+	}
+	gv := g.use("github.com/booldesign/gvalid")
+	val, vt := f.val(), f.valType()
+	kind := gotypes.FieldType(vt).Kind
+
+	switch r.Name {
+	case "required":
+		if f.null != nil {
+			invalid := "nil"
+			if f.v.Exported() {
+				invalid = g.typeString(f.typ) + "{}"
+			}
+			cvEq, _, _ := g.zero("cv", vt)
+			return fmt.Sprintf("if !%s.Valid {\n%s} else if %s {\n%s}\n", f.acc,
+				g.fail(f, r, invalid, "struct", gv+".ValidateValCanNotEmpty"), cvEq,
+				g.failValue(f, r, gv+".ValidateValCanNotEmpty")), nil
+		}
+		if f.ptr {
+			return fmt.Sprintf("if %s {\n%s}\n", eq, g.fail(f, r, "nil", "", gv+".ValidateValCanNotEmpty")), nil
+		}
+		return fmt.Sprintf("if %s {\n%s}\n", eq, g.failValue(f, r, gv+".ValidateValCanNotEmpty")), nil
+
+	case "empty":
+		return fmt.Sprintf("if %s {\n%s}\n", ne, g.failValue(f, r, gv+".ValidateValMustEmpty")), nil
+
+	case "gt", "gte", "lt", "lte", "len":
+		suffix := map[string]string{"gt": "Gt", "gte": "Gte", "lt": "Lt", "lte": "Lte", "len": "Len"}[r.Name]
+		var expr, msg string
+		switch {
+		case kind == reflect.String:
+			expr = g.use("unicode/utf8") + ".RuneCountInString(" + g.conv("string", val, vt) + ")"
+			msg = g.sprintf("ValidateValNot"+suffix+"String", r.Param())
+		case kind == reflect.Slice || kind == reflect.Map || kind == reflect.Array:
+			expr = "len(" + val + ")"
+			msg = g.sprintf("ValidateValNot"+suffix+"Slice", r.Param())
+		case kind == reflect.Float32 || kind == reflect.Float64:
+			expr = g.conv("float64", val, vt)
+			msg = g.sprintf("ValidateValNot"+suffix+"Float", "float64("+r.Param()+")")
+		default:
+			expr = g.conv("int", val, vt)
+			msg = g.sprintf("ValidateValNot"+suffix+"Int", r.Param())
+		}
+		return fmt.Sprintf("if %s && !(%s %s %s) {\n%s}\n", ne, expr, compareOps[r.Name], r.Param(), g.failValue(f, r, msg)), nil
+
+	case "date":
+		layout := strconv.Quote(r.Param())
+		return fmt.Sprintf("if %s {\nif _, err := %s.ParseInLocation(%s, %s, %s.Location()); err != nil {\n%s}\n}\n",
+			ne, g.use("time"), layout, g.conv("string", val, vt), gv, g.failValue(f, r, g.sprintf("ValidateValDateFormatErr", layout))), nil
+
+	case "strGte", "strLte":
+		op, msg := ">=", "ValidateValNotStrGte"
+		if r.Name == "strLte" {
+			op, msg = "<=", "ValidateValNotStrLte"
+		}
+		bound := strconv.Quote(r.Param())
+		return fmt.Sprintf("if %s && !(%s %s %s) {\n%s}\n", ne, g.conv("string", val, vt), op, bound, g.failValue(f, r, g.sprintf(msg, bound))), nil
+
+	case "in":
+		conds := make([]string, len(r.Params))
+		for i, p := range r.Params {
+			if kind == reflect.String {
+				conds[i] = g.conv("string", val, vt) + " != " + strconv.Quote(p.Value)
+			} else {
+				conds[i] = g.conv("int64", val, vt) + " != " + p.Value
+			}
+		}
+		msg := g.sprintf("ValidateValNotExists", strconv.Quote(r.QuotedParams()))
+		return fmt.Sprintf("if %s && %s {\n%s}\n", ne, strings.Join(conds, " && "), g.failValue(f, r, msg)), nil
+
+	case "sin":
+		conds := make([]string, len(r.Params))
+		for i, p := range r.Params {
+			if gotypes.FieldType(vt).Type == "[]string" {
+				conds[i] = "e != " + strconv.Quote(p.Value)
+			} else {
+				conds[i] = "e != " + p.Value
+			}
+		}
+		msg := g.sprintf("ValidateValNotExistsSlice", strconv.Quote(r.QuotedParams()))
+		return fmt.Sprintf("if %s {\nfor _, e := range %s {\nif %s {\n%sbreak\n}\n}\n}\n", ne, val, strings.Join(conds, " && "), g.failValue(f, r, msg)), nil
+
+	case "regex":
+		return fmt.Sprintf("if %s && !%s.MatchString(%s) {\n%s}\n", ne, g.regexp(r.Param()), g.conv("string", val, vt), g.failValue(f, r, gv+".ValidateValNotFormatErr")), nil
+
+	case "email", "mobile", "base64", "ip", "url", "idCard":
+		return fmt.Sprintf("if %s && !%s.%s(%s) {\n%s}\n", ne, gv, stringFuncs[r.Name], g.conv("string", val, vt), g.failValue(f, r, gv+".ValidateValNotFormatErr")), nil
+
+	case "numeric":
+		return fmt.Sprintf("if %s {\nfor _, c := range %s {\nif c < '0' || c > '9' {\n%sbreak\n}\n}\n}\n", ne, g.conv("string", val, vt), g.failValue(f, r, gv+".ValidateValNotNumericErr")), nil
+
+	case "distinct":
+		et := vt.Underlying().(*types.Slice).Elem()
+		msg := g.sprintf("ValidateValMustDistinct", fmt.Sprintf("g.Display(%q, %q, %s)", f.v.Name(), f.masker, val))
+		return fmt.Sprintf("if %s {\nseen := make(map[%s]struct{}, len(%s))\nfor _, e := range %s {\nif _, ok := seen[e]; ok {\n%sbreak\n}\nseen[e] = struct{}{}\n}\n}\n",
+			ne, g.typeString(et), val, val, g.failValue(f, r, msg)), nil
+
+	case "default":
+		if f.ptr {
+			return "", errors.New("指针字段不支持 default")
+		}
+		if !f.v.Exported() {
+			return "", errors.New("未导出的字段不支持 default")
+		}
+		lit := r.Param()
+		if kind == reflect.String {
+			lit = strconv.Quote(lit)
+		}
+		if f.null != nil {
+			// Null* 类型 Valid 为 false 时不设置默认值
+			cvEq, _, _ := g.zero("cv", vt)
+			return fmt.Sprintf("if %s.Valid && %s {\ncv = %s\n}\n", f.acc, cvEq, lit), nil
+		}
+		return fmt.Sprintf("if %s {\n%s = %s\n}\n", eq, f.acc, lit), nil
+
+	case "trimSpace":
+		if !f.v.Exported() {
+			return "", errors.New("未导出的字段不支持 trimSpace")
+		}
+		trimmed := g.use("strings") + ".TrimSpace(" + g.conv("string", val, vt) + ")"
+		if b, ok := vt.(*types.Basic); !ok || b.Kind() != types.String {
+			trimmed = g.typeString(vt) + "(" + trimmed + ")"
+		}
+		return fmt.Sprintf("if %s {\n%s = %s\n}\n", ne, val, trimmed), nil
+
+	case "enum":
+		if len(r.Params) > 0 {
+			return fmt.Sprintf("if %s {\nif msg := %s.CheckEnum(%q, %s); msg != \"\" {\n%s}\n}\n", ne, gv, r.Param(), val, g.failValue(f, r, "msg")), nil
+		}
+		return g.enum(f, r, ne)
+
+	case gvalid.SensitiveTag:
+		return "", nil
+
+	case "immutable", "transitions":
+		// 仅 ValidateUpdate 时验证, 没有旧值
+		return "", nil
+	}
+	return "", fmt.Errorf("未知的规则 %s", r.Name)
+}
+
+// enum 实现 gvalid.Enum 的字段, slice 和 array 验证每个元素
+func (g *generator) enum(f *field, r gvalid.Rule, ne string) (string, error) {
+	gv := g.use("github.com/booldesign/gvalid")
+	val, vt := f.val(), f.valType()
+	var et types.Type
+	switch u := vt.Underlying().(type) {
+	case *types.Slice:
+		et = u.Elem()
+	case *types.Array:
+		et = u.Elem()
+	case *types.Map:
+		return "", errors.New("enum 不支持 map")
+	default:
+		named, ok := vt.(*types.Named)
+		if !ok || !gotypes.HasMethod(vt, "IsValid", 0, "bool") {
+			return "", fmt.Errorf("%s 没有实现 Enum 接口", g.typeString(vt))
+		}
+		msg := fmt.Sprintf("%s.EnumMessage(%q)", gv, named.Obj().Name())
+		return fmt.Sprintf("if %s && !%s.IsValid() {\n%s}\n", ne, f.operand(), g.failValue(f, r, msg)), nil
+	}
+
+	named, ok := gotypes.Deref(et).(*types.Named)
+	if !ok || !gotypes.HasMethod(named, "IsValid", 0, "bool") {
+		return "", fmt.Errorf("%s 没有实现 Enum 接口", g.typeString(et))
+	}
+	cond := "!" + f.operand() + "[i].IsValid()"
+	if _, ok = et.(*types.Pointer); ok {
+		cond = f.operand() + "[i] != nil && " + cond
+	}
+	msg := fmt.Sprintf("%s.EnumMessage(%q)", gv, named.Obj().Name())
+	return fmt.Sprintf("if %s {\nfor i := range %s {\nif %s {\n%sbreak\n}\n}\n}\n", ne, val, cond, g.failValue(f, r, msg)), nil
+}
+
+// dive 嵌套验证, 结构体须为同一个包中的命名类型
+func (g *generator) dive(f *field) (string, error) {
+	if f.null != nil {
+		return "", errors.New("dive 不支持自定义类型")
+	}
+	nested := func(t types.Type) (string, error) {
+		named, ok := t.(*types.Named)
+		if !ok || !gotypes.IsStruct(named) {
+			return "", fmt.Errorf("dive 不支持 %s 类型", g.typeString(t))
+		}
+		if named.Obj().Pkg() != g.pkg {
+			return "", fmt.Errorf("dive 的结构体 %s 不在当前包中, 不能生成", g.typeString(t))
+		}
+		g.enqueue(named.Obj())
+		return named.Obj().Name(), nil
+	}
+	exported := strconv.FormatBool(f.v.Exported())
+
+	if s, ok := f.elem.Underlying().(*types.Slice); ok {
+		if f.ptr {
+			return "", fmt.Errorf("dive 不支持 %s 类型", g.typeString(f.typ))
+		}
+		et := s.Elem()
+		skip := ""
+		if p, ok := et.(*types.Pointer); ok {
+			et = p.Elem()
+			skip = "if " + f.acc + "[i] == nil {\ncontinue\n}\n"
+		}
+		if _, err := nested(et); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("for i := range %s {\n%sprev := g.Enter(%q+\"[\"+%s.Itoa(i)+\"]\", %s)\nif err := %s[i].gvalidValidate(g, nil); err != nil {\nreturn err\n}\ng.Leave(prev)\n}\n",
+			f.acc, skip, f.v.Name(), g.use("strconv"), exported, f.acc), nil
+	}
+
+	if _, err := nested(f.elem); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if f.ptr {
+		if !f.v.Exported() {
+			return "", errors.New("未导出的指针字段不支持 dive")
+		}
+		fmt.Fprintf(&b, "if %s == nil {\n%s = new(%s)\n}\n", f.acc, f.acc, g.typeString(f.elem))
+	}
+	path, embeddedIn := strconv.Quote(f.v.Name()), "nil"
+	if f.v.Anonymous() {
+		path, embeddedIn = `""`, "x"
+	}
+	fmt.Fprintf(&b, "{\nprev := g.Enter(%s, %s)\nif err := %s.gvalidValidate(g, %s); err != nil {\nreturn err\n}\ng.Leave(prev)\n}\n",
+		path, exported, f.acc, embeddedIn)
+	return b.String(), nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 19:00
+ * @Desc:
+ */
+
+func TestGenerate(t *testing.T) {
+	Convey("test generated file is up to date", t, func() {
+		src, err := Generate("../../internal/conformance", []string{"Order"})
+		So(err, ShouldBeNil)
+		golden, err := os.ReadFile("../../internal/conformance/order_gvalid.go")
+		So(err, ShouldBeNil)
+		// 不一致时在 internal/conformance 中执行 go generate
+		So(string(src), ShouldEqual, string(golden))
+	})
+
+	Convey("test generate errors", t, func() {
+		_, err := Generate("testdata/bad", []string{"Bad"})
+		So(err, ShouldNotBeNil)
+		msgs := strings.Split(err.Error(), "\n")
+		for i, msg := range msgs {
+			msgs[i] = msg[strings.Index(msg, "Bad."):]
+		}
+		So(msgs, ShouldResemble, []string{
+			"Bad.Unknown: requird 第 1 列: 未知的规则 requird",
+			"Bad.Param: gt 第 1 列: gt 的参数 a 应为整数",
+			"Bad.Default: default 第 1 列: 指针字段不支持 default",
+			"Bad.Price: 不支持自定义类型 bad.Money, 只支持 database/sql 的 Null* 类型",
+			"Bad.Int16: 不支持自定义类型 sql.NullInt16, 只支持 database/sql 的 Null* 类型",
+			"Bad.Created: dive 第 1 列: dive 的结构体 time.Time 不在当前包中, 不能生成",
+			"Bad.Quoted: 引号未闭合",
+			"Bad.Pattern: 正则表达式错误: error parsing regexp: missing closing ]: `[a-`",
+			"Bad.Nested: 不支持多级指针",
+			"Bad.trimmed: trimSpace 第 1 列: 未导出的字段不支持 trimSpace",
+		})
+
+		_, err = Generate("testdata/bad", []string{"NotExists"})
+		So(err, ShouldNotBeNil)
+	})
+}
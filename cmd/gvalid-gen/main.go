@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 17:00
+ * @Desc: 根据 valid tag 生成不使用反射的验证代码
+ */
+
+var (
+	typeNames = flag.String("type", "", "逗号分隔的结构体类型名, 必填, dive 的同包结构体会一并生成")
+	output    = flag.String("output", "", "输出文件名, 默认为 <第一个类型名小写>_gvalid.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of gvalid-gen:\n")
+	fmt.Fprintf(os.Stderr, "\tgvalid-gen -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "\t//go:generate go run github.com/booldesign/gvalid/cmd/gvalid-gen -type=T\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gvalid-gen: ")
+	flag.Usage = usage
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	names := strings.Split(*typeNames, ",")
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	src, err := Generate(dir, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	name := *output
+	if name == "" {
+		name = strings.ToLower(names[0]) + "_gvalid.go"
+	}
+	if err = os.WriteFile(filepath.Join(dir, name), src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,24 @@
+package bad
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"time"
+)
+
+type Money struct{ cents int64 }
+
+func (m Money) Value() (driver.Value, error) { return m.cents, nil }
+
+type Bad struct {
+	Unknown string        `valid:"requird"`
+	Param   int           `valid:"gt=a"`
+	Default *string       `valid:"default=a"`
+	Price   Money         `valid:"required"`
+	Int16   sql.NullInt16 `valid:"required"`
+	Created time.Time     `valid:"dive"`
+	Quoted  string        `valid:"in='a"`
+	Pattern string        `valid:"regex=(/[a-/)"`
+	Nested  **Bad         `valid:"dive"`
+	trimmed string        `valid:"trimSpace"`
+}
@@ -14,6 +14,27 @@ const (
 	tagKeySep         = "="
 	skipValidationTag = "-"
 	validFuncPrefix   = "Rule"
+	diveFunc          = validFuncPrefix + "Dive"
+)
+
+const (
+	// SensitiveTag 脱敏选项, 如 valid:"mobile,sensitive=mobile", 不是验证规则
+	SensitiveTag = "sensitive"
+)
+
+const (
+	groupsTagName = "groups"
+	groupSep      = "@"
+	groupOr       = "|"
+)
+
+const (
+	// CustomRule 自定义验证 (SetError) 产生的 Error.Rule
+	CustomRule = "custom"
+	// TypeRule ValidateJSON 类型错误产生的 Error.Rule
+	TypeRule = "type"
+	// UnknownRule ValidateJSON 未知字段产生的 Error.Rule
+	UnknownRule = "unknown"
 )
 
 const (
@@ -26,6 +47,7 @@ const (
 	ValidateMethodNotAllowSth = "验证方法 %s 不允许 %v"
 	ValidateValTypeErr        = "验证规则写法有误"
 	ValidateValCanNotEmpty    = "不能为空或零值"
+	ValidateValMustEmpty      = "必须为空或零值"
 	ValidateValNotGtString    = "长度必须是大于 %d"
 	ValidateValNotGtSlice     = "长度必须是大于 %d"
 	ValidateValNotGtInt       = "必须是大于 %d"
@@ -43,11 +65,18 @@ const (
 	ValidateValNotLteInt      = "必须是小于等于 %d"
 	ValidateValNotLteFloat    = "必须是小于等于 %.2f"
 	ValidateValNotLenString   = "长度必须是等于 %d"
+	ValidateValNotStrGte      = "必须是大于等于 %s"
+	ValidateValNotStrLte      = "必须是小于等于 %s"
 	ValidateValNotLenSlice    = "长度必须是等于 %d"
 	ValidateValDateFormatErr  = "时间格式错误 %s"
 	ValidateValNotExists      = "必须是 %s 其中一个"
 	ValidateValNotExistsSlice = "必须是 %s 其中一个或多个"
+	ValidateValEnumErr        = "不是有效的值"
 	ValidateValNotFormatErr   = "格式错误"
 	ValidateValNotNumericErr  = "必须是有效的数字字符"
 	ValidateValMustDistinct   = "含有重复的值 %+v"
+	ValidateValTypeMismatch   = "类型错误, 应为 %s"
+	ValidateValImmutable      = "不允许修改"
+	ValidateValTransitionErr  = "不允许从 %s 变更为 %s"
+	ValidateValUnknownField   = "不是有效的字段"
 )
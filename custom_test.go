@@ -0,0 +1,103 @@
+package gvalid
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 11:40
+ * @Desc:
+ */
+
+// customRange 值接收者
+type customRange struct {
+	Min int `valid:"gte=0" name:"最小值"`
+	Max int `valid:"gte=0" name:"最大值"`
+}
+
+func (r customRange) Valid(v *Validation) {
+	if r.Min > r.Max {
+		v.SetError("Max", "最大值", "必须大于等于最小值")
+	}
+}
+
+// customSku 指针接收者, 带 context
+type customSku struct {
+	Code string `valid:"required" name:"编号"`
+}
+
+type skuKey struct{}
+
+func (s *customSku) ValidCtx(ctx context.Context, v *Validation, path string) error {
+	exists, _ := ctx.Value(skuKey{}).(map[string]bool)
+	if exists == nil {
+		return errors.New("sku 服务不可用")
+	}
+	if s.Code != "" && !exists[s.Code] {
+		v.SetError("Code", "编号", "不存在 "+path)
+	}
+	return nil
+}
+
+type customBase struct {
+	Title string `valid:"required" name:"标题"`
+}
+
+func (b *customBase) Valid(v *Validation) {
+	if b.Title == "test" {
+		v.SetError("Title", "标题", "不能为 test")
+	}
+}
+
+type customGoods struct {
+	customBase
+	Price customRange   `valid:"dive" name:"价格"`
+	Skus  []*customSku  `valid:"dive" name:"SKU"`
+	Specs []customRange `valid:"dive" name:"规格"`
+	Ptr   *customRange  `valid:"dive" name:"指针"`
+}
+
+func TestNestedCustom(t *testing.T) {
+	ctx := context.WithValue(context.Background(), skuKey{}, map[string]bool{"S1": true})
+	g := customGoods{
+		customBase: customBase{Title: "test"},
+		Price:      customRange{Min: 10, Max: 1},
+		Skus:       []*customSku{{Code: "S1"}, {Code: "S2"}},
+		Specs:      []customRange{{Min: 1, Max: 2}, {Min: 3, Max: 2}},
+		Ptr:        &customRange{Min: 2, Max: 1},
+	}
+
+	Convey("test nested custom", t, func() {
+		v := &Validation{}
+		b, err := v.ValidContext(ctx, &g)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(errorPaths(v), ShouldResemble, []string{"Price.Max", "Skus[1].Code", "Specs[1].Max", "Ptr.Max", "Title"})
+		So(v.ErrorsByPath["Skus[1].Code"][0].Message, ShouldEqual, "不存在 Skus[1]")
+	})
+
+	Convey("test value of struct", t, func() {
+		v := &Validation{}
+		b, err := v.Valid(customRange{Min: 2, Max: 1})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+
+		v = &Validation{}
+		b, err = v.ValidContext(ctx, customSku{Code: "S2"})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+	})
+
+	Convey("test custom error", t, func() {
+		v := &Validation{}
+		_, err := v.Valid(&g)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "sku 服务不可用")
+	})
+}
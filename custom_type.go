@@ -0,0 +1,114 @@
+package gvalid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strconv"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 13:30
+ * @Desc: 自定义类型, 取出实际要验证的值
+ */
+
+// CustomTypeFunc 返回字段实际要验证的值, 返回 nil 视为零值
+type CustomTypeFunc func(field reflect.Value) interface{}
+
+var (
+	customTypeFuncs = make(map[reflect.Type]CustomTypeFunc)
+	valuerType      = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+func init() {
+	RegisterCustomTypeFunc(nullValue,
+		sql.NullString{}, sql.NullInt64{}, sql.NullInt32{}, sql.NullFloat64{}, sql.NullBool{}, sql.NullTime{})
+}
+
+// RegisterCustomTypeFunc 为 types 注册自定义类型函数, 如 sql.NullString, 自定义的 Money 类型
+// 传入值或指针均注册其指向的类型, 同 RegisterStructValidation, fn 收到的 field 不是指针
+// 内置 database/sql 的 Null* 类型, 未注册但实现了 driver.Valuer 的类型使用 Value() 的返回值
+// Value() 返回字符串的小数类型 (如 decimal.Decimal) 按字符串验证, gt=0 比较的是长度, 须注册 NumericValue
+func RegisterCustomTypeFunc(fn CustomTypeFunc, types ...interface{}) {
+	for _, t := range types {
+		customTypeFuncs[indirectType(reflect.TypeOf(t))] = fn
+	}
+}
+
+// NumericValue 自定义类型函数, driver.Valuer 返回的数字字符串转为 float64, 按数字验证
+// 不是数字的字符串及其它类型的值原样返回
+//
+//	gvalid.RegisterCustomTypeFunc(gvalid.NumericValue, decimal.Decimal{})
+func NumericValue(field reflect.Value) interface{} {
+	valuer, ok := asValuer(field)
+	if !ok {
+		return nil
+	}
+	v, err := valuer.Value()
+	if err != nil {
+		return nil
+	}
+	if s, ok := v.(string); ok {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+// nullValue database/sql 的 Null* 类型, Valid 为 false 时返回 nil, 否则返回第一个字段
+func nullValue(field reflect.Value) interface{} {
+	if !field.FieldByName("Valid").Bool() {
+		return nil
+	}
+	return field.Field(0).Interface()
+}
+
+// customValue 自定义类型的实际值, 不是自定义类型时 ok 为 false
+// 返回的值可设置, 修改不会影响原字段; 返回 nil 时为原类型的零值
+func customValue(vOf reflect.Value) (val reflect.Value, ok bool) {
+	if !vOf.IsValid() {
+		return
+	}
+	if vOf.Kind() == reflect.Ptr {
+		if vOf.IsNil() {
+			return
+		}
+		vOf = vOf.Elem()
+	}
+	if !vOf.CanInterface() {
+		return
+	}
+
+	var v interface{}
+	if fn, exists := customTypeFuncs[vOf.Type()]; exists {
+		v = fn(vOf)
+	} else if valuer, isValuer := asValuer(vOf); isValuer {
+		var err error
+		if v, err = valuer.Value(); err != nil {
+			v = nil
+		}
+	} else {
+		return
+	}
+
+	if v == nil {
+		return reflect.New(vOf.Type()).Elem(), true
+	}
+	val = reflect.New(reflect.TypeOf(v)).Elem()
+	val.Set(reflect.ValueOf(v))
+	return val, true
+}
+
+// asValuer 值或指针实现了 driver.Valuer
+func asValuer(vOf reflect.Value) (driver.Valuer, bool) {
+	if vOf.Type().Implements(valuerType) {
+		return vOf.Interface().(driver.Valuer), true
+	}
+	if vOf.CanAddr() && reflect.PtrTo(vOf.Type()).Implements(valuerType) {
+		return vOf.Addr().Interface().(driver.Valuer), true
+	}
+	return nil, false
+}
@@ -0,0 +1,133 @@
+package gvalid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 14:10
+ * @Desc:
+ */
+
+// Money 分为单位的金额
+type Money struct {
+	cents int64
+}
+
+// Cent 分
+type Cent int64
+
+func (c Cent) Value() (driver.Value, error) {
+	return int64(c), nil
+}
+
+// Decimal 以字符串保存的小数
+type Decimal string
+
+func (d Decimal) Value() (driver.Value, error) {
+	return string(d), nil
+}
+
+func TestCustomType(t *testing.T) {
+	RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return float64(field.Interface().(Money).cents) / 100
+	}, Money{})
+	defer delete(customTypeFuncs, reflect.TypeOf(Money{}))
+
+	type WUser struct {
+		Name     sql.NullString  `valid:"required,lte=5" name:"姓名"`
+		Nickname *sql.NullString `valid:"required,gte=2" name:"昵称"`
+		Age      sql.NullInt64   `valid:"gt=0" name:"年龄"`
+		Score    sql.NullInt32   `valid:"in=1 2 3" name:"等级"`
+		Price    Money           `valid:"gt=0.5" name:"价格"`
+		Discount Cent            `valid:"lte=100" name:"折扣"`
+	}
+
+	Convey("test valid", t, func() {
+		u := &WUser{
+			Name:     sql.NullString{String: "wei", Valid: true},
+			Nickname: &sql.NullString{String: "booldesign", Valid: true},
+			Age:      sql.NullInt64{Int64: 18, Valid: true},
+			Score:    sql.NullInt32{Int32: 2, Valid: true},
+			Price:    Money{cents: 100},
+			Discount: 80,
+		}
+		v := &Validation{}
+		b, err := v.Valid(u)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+	})
+
+	Convey("test invalid", t, func() {
+		u := &WUser{
+			Name:     sql.NullString{String: "wei", Valid: false},
+			Nickname: &sql.NullString{String: "b", Valid: true},
+			Age:      sql.NullInt64{Int64: -1, Valid: true},
+			Score:    sql.NullInt32{Int32: 4, Valid: true},
+			Price:    Money{cents: 10},
+			Discount: 120,
+		}
+		v := &Validation{}
+		b, err := v.Valid(u)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(errorPaths(v), ShouldResemble, []string{"Name", "Nickname", "Age", "Score", "Price", "Discount"})
+		So(v.ErrorsMap["Age"][0].Value, ShouldEqual, -1)
+		So(v.ErrorsMap["Age"][0].Kind, ShouldEqual, "int64")
+		So(v.ErrorsMap["Price"][0].Value, ShouldEqual, 0.1)
+	})
+
+	Convey("test null is zero", t, func() {
+		u := &WUser{
+			Name:     sql.NullString{String: "wei", Valid: true},
+			Nickname: &sql.NullString{String: "booldesign", Valid: true},
+			Age:      sql.NullInt64{Int64: -1, Valid: false},
+		}
+		v := &Validation{}
+		b, err := v.Valid(u)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+	})
+
+	Convey("test register pointer type", t, func() {
+		type Goods struct {
+			Price Money  `valid:"gt=0.5"`
+			Cost  *Money `valid:"gt=0.5"`
+		}
+		RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+			return float64(field.Interface().(Money).cents) / 100
+		}, &Money{})
+
+		v := &Validation{}
+		_, _ = v.Valid(&Goods{Price: Money{cents: 10}, Cost: &Money{cents: 100}})
+		So(errorPaths(v), ShouldResemble, []string{"Price"})
+	})
+
+	Convey("test numeric value", t, func() {
+		type Goods struct {
+			Price Decimal `valid:"gt=0"`
+		}
+		// 未注册时按字符串验证, 比较长度
+		v := &Validation{}
+		b, _ := v.Valid(&Goods{Price: "-1.5"})
+		So(b, ShouldBeTrue)
+
+		RegisterCustomTypeFunc(NumericValue, Decimal(""))
+		defer delete(customTypeFuncs, reflect.TypeOf(Decimal("")))
+		v = &Validation{}
+		b, _ = v.Valid(&Goods{Price: "-1.5"})
+		So(b, ShouldBeFalse)
+		So(v.ErrorsMap["Price"][0].Value, ShouldEqual, -1.5)
+
+		v = &Validation{}
+		b, _ = v.Valid(&Goods{Price: "0.01"})
+		So(b, ShouldBeTrue)
+	})
+}
@@ -0,0 +1,345 @@
+package gvalid
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/23 15:00
+ * @Desc: 生成接口参数文档, 字段, 类型, 是否必填, 约束及说明
+ */
+
+// ParamDoc 参数文档的一行
+type ParamDoc struct {
+	// Name 参数名, 为 JSON 字段名, 嵌套字段如 gallery.imgUrl, slice 元素的字段如 list[].imgUrl
+	Name string
+	// Type JSON 类型, 如 string, integer, number, boolean, object, array[object]
+	Type string
+	// Required 有 required 规则
+	Required bool
+	// Constraints 可读的约束, 如 长度 1~10, 必须是 1 2 其中一个
+	Constraints []string
+	// Description name tag
+	Description string
+}
+
+// ParamDocs 生成 obj 的参数文档, obj 为结构体或结构体指针, 只包含验证场景 groups 下执行的规则
+// dive 的结构体的字段紧跟在其后, 匿名结构体的字段同 encoding/json 展开, json:"-" 的字段省略
+func ParamDocs(obj interface{}, groups ...string) ([]*ParamDoc, error) {
+	ts, err := DescribeType(reflect.TypeOf(obj))
+	if err != nil {
+		return nil, err
+	}
+	var docs []*ParamDoc
+	paramDocs(ts, "", groups, map[*TypeSchema]bool{ts: true}, &docs)
+	return docs, nil
+}
+
+// paramDocs visiting 为正在展开的结构体, 递归的类型不再展开
+func paramDocs(ts *TypeSchema, prefix string, groups []string, visiting map[*TypeSchema]bool, docs *[]*ParamDoc) {
+	for _, fs := range ts.Fields {
+		rules := fs.Rules.ForGroups(groups...)
+		if fs.JSONName == "" {
+			if fs.Anonymous && fs.Dive != nil && rules.Has(ruleName(diveFunc)) && !visiting[fs.Dive] {
+				visiting[fs.Dive] = true
+				paramDocs(fs.Dive, prefix, groups, visiting, docs)
+				delete(visiting, fs.Dive)
+			}
+			continue
+		}
+		ft := NewFieldType(fs.Type)
+		doc := &ParamDoc{
+			Name:        prefix + fs.JSONName,
+			Type:        DocType(ft),
+			Required:    rules.Has("required"),
+			Constraints: DocConstraints(rules, ft),
+			Description: fs.Label,
+		}
+		if f, ok := ts.Type.FieldByName(fs.Name); ok && jsonStringOption(f) {
+			doc.Type = "string"
+		}
+		*docs = append(*docs, doc)
+		if fs.Dive == nil || !rules.Has(ruleName(diveFunc)) || visiting[fs.Dive] {
+			continue
+		}
+		// 每层 slice 加一个 []
+		sub := doc.Name
+		for t := fs.Type; t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array; t = t.Elem() {
+			if t.Kind() != reflect.Ptr {
+				sub += "[]"
+			}
+		}
+		sub += "."
+		visiting[fs.Dive] = true
+		paramDocs(fs.Dive, sub, groups, visiting, docs)
+		delete(visiting, fs.Dive)
+	}
+}
+
+// DocType 字段的 JSON 类型, 自定义类型为其 Go 类型名
+func DocType(t *FieldType) string {
+	if t == nil {
+		return ""
+	}
+	kind, ok := docKind(t)
+	if !ok {
+		return t.Type
+	}
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		// 多维 slice 只有一层 Elem; []byte 以 base64 字符串传输
+		if t.Elem == nil {
+			return "array"
+		}
+		if t.Elem.Kind == reflect.Uint8 {
+			return "string"
+		}
+		return "array[" + DocType(t.Elem) + "]"
+	case reflect.Struct:
+		if t.Type == "time.Time" {
+			return "string"
+		}
+		return "object"
+	case reflect.Map:
+		return "object"
+	}
+	return t.Type
+}
+
+// nullKinds database/sql 的 Null* 类型的值的类型
+var nullKinds = map[string]reflect.Kind{
+	"sql.NullString":  reflect.String,
+	"sql.NullInt64":   reflect.Int64,
+	"sql.NullInt32":   reflect.Int32,
+	"sql.NullInt16":   reflect.Int16,
+	"sql.NullByte":    reflect.Uint8,
+	"sql.NullFloat64": reflect.Float64,
+	"sql.NullBool":    reflect.Bool,
+}
+
+// docKind 验证的值的类型, 自定义类型只支持 database/sql 的 Null* 类型
+func docKind(t *FieldType) (reflect.Kind, bool) {
+	if !t.Custom {
+		return t.Kind, true
+	}
+	kind, ok := nullKinds[t.Type]
+	return kind, ok
+}
+
+// docFormats 格式类规则的约束
+var docFormats = map[string]string{
+	"email":     "邮箱格式",
+	"mobile":    "手机号码格式",
+	"idCard":    "身份证号码格式",
+	"url":       "URL 格式",
+	"ip":        "IP 地址格式",
+	"base64":    "Base64 格式",
+	"numeric":   ValidateValNotNumericErr,
+	"empty":     ValidateValMustEmpty,
+	"distinct":  "不能重复",
+	"trimSpace": "去除首尾空格",
+	"immutable": ValidateValImmutable,
+}
+
+// DocConstraints 规则的可读约束, 如 长度 1~10, 必须是 1 2 其中一个, t 用于区分长度与取值范围
+// required, dive 及 sensitive 不是约束, 不包含在内; gt, gte, lt, lte 合并为一条
+func DocConstraints(rules RuleSet, t *FieldType) []string {
+	var cs []string
+	bounds := -1
+	for _, r := range rules {
+		switch r.Name {
+		case "required", ruleName(diveFunc), SensitiveTag:
+		case "gt", "gte", "lt", "lte":
+			if bounds == -1 {
+				bounds = len(cs)
+				cs = append(cs, "")
+			}
+		case "len":
+			cs = append(cs, "长度 "+r.Param())
+		case "in":
+			cs = append(cs, fmt.Sprintf(ValidateValNotExists, r.QuotedParams()))
+		case "sin":
+			cs = append(cs, fmt.Sprintf(ValidateValNotExistsSlice, r.QuotedParams()))
+		case "enum":
+			cs = append(cs, docEnum(r, t))
+		case toLowerCamel(RegexFunc):
+			cs = append(cs, "匹配正则 "+r.Param())
+		case "date":
+			cs = append(cs, "时间格式 "+r.Param())
+		case "strGte":
+			cs = append(cs, fmt.Sprintf(ValidateValNotStrGte, r.Param()))
+		case "strLte":
+			cs = append(cs, fmt.Sprintf(ValidateValNotStrLte, r.Param()))
+		case "default":
+			cs = append(cs, "默认值 "+r.Param())
+		case "transitions":
+			cs = append(cs, "允许的变更 "+r.Param())
+		default:
+			if s, ok := docFormats[r.Name]; ok {
+				cs = append(cs, s)
+			} else {
+				cs = append(cs, strings.TrimSpace(r.Name+" "+r.Param()))
+			}
+		}
+	}
+	if bounds >= 0 {
+		if s := docBounds(rules, t); s != "" {
+			cs[bounds] = s
+		} else {
+			cs = append(cs[:bounds], cs[bounds+1:]...)
+		}
+	}
+	return cs
+}
+
+// docBounds gt, gte, lt, lte 合并后的约束, 数字为取值范围, 字符串, slice, map 为长度
+func docBounds(rules RuleSet, t *FieldType) string {
+	kind := reflect.Invalid
+	if t != nil {
+		kind, _ = docKind(t)
+	}
+	if kind == reflect.String || kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map {
+		// 长度为整数, 转换为闭区间; 必填时长度至少为 1
+		min, max := -1, -1
+		for _, r := range rules {
+			n, err := strconv.Atoi(r.Param())
+			if err != nil {
+				continue
+			}
+			switch r.Name {
+			case "gt":
+				min = n + 1
+			case "gte":
+				min = n
+			case "lt":
+				max = n - 1
+			case "lte":
+				max = n
+			}
+		}
+		if min == -1 && max >= 0 && rules.Has("required") {
+			min = 1
+		}
+		switch {
+		case min >= 0 && max >= 0:
+			return fmt.Sprintf("长度 %d~%d", min, max)
+		case min >= 0:
+			return fmt.Sprintf("长度 >= %d", min)
+		case max >= 0:
+			return fmt.Sprintf("长度 <= %d", max)
+		}
+		return ""
+	}
+
+	ops := map[string]string{"gt": ">", "gte": ">=", "lt": "<", "lte": "<="}
+	var lower, upper *Rule
+	for i, r := range rules {
+		switch r.Name {
+		case "gt", "gte":
+			lower = &rules[i]
+		case "lt", "lte":
+			upper = &rules[i]
+		}
+	}
+	if lower != nil && upper != nil && lower.Name == "gte" && upper.Name == "lte" {
+		return fmt.Sprintf("取值 %s~%s", lower.Param(), upper.Param())
+	}
+	var parts []string
+	for _, r := range []*Rule{lower, upper} {
+		if r != nil {
+			parts = append(parts, ops[r.Name]+" "+r.Param())
+		}
+	}
+	return "取值 " + strings.Join(parts, " 且 ")
+}
+
+// docEnum 已注册的枚举列出所有值, 否则为枚举类型名
+func docEnum(r Rule, t *FieldType) string {
+	name := r.Param()
+	if name == "" && t != nil {
+		et := t
+		if t.Elem != nil && (t.Kind == reflect.Slice || t.Kind == reflect.Array) {
+			et = t.Elem
+		}
+		name = et.Type[strings.LastIndex(et.Type, ".")+1:]
+	}
+	if values, ok := EnumValues(name); ok {
+		return fmt.Sprintf(ValidateValNotExists, enumString(values))
+	}
+	return "枚举 " + name
+}
+
+// docHeaders 参数表格的表头
+var docHeaders = []string{"参数", "类型", "必填", "约束", "说明"}
+
+func (d *ParamDoc) cells() []string {
+	required := "否"
+	if d.Required {
+		required = "是"
+	}
+	return []string{d.Name, d.Type, required, strings.Join(d.Constraints, "; "), d.Description}
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`", "\n", " ")
+
+// markdownTag 会被解析为 HTML 的 <
+var markdownTag = regexp.MustCompile(`<([A-Za-z/!?])`)
+
+// MarkdownTable 参数文档的 Markdown 表格
+func MarkdownTable(docs []*ParamDoc) string {
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(docHeaders, " | ") + " |\n")
+	b.WriteString(strings.Repeat("| --- ", len(docHeaders)) + "|\n")
+	for _, d := range docs {
+		cells := d.cells()
+		for i, c := range cells {
+			cells[i] = markdownTag.ReplaceAllString(markdownEscaper.Replace(c), "&lt;$1")
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return b.String()
+}
+
+// HTMLTable 参数文档的 HTML 表格, 多条约束以 <br> 分隔
+func HTMLTable(docs []*ParamDoc) string {
+	var b strings.Builder
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for _, h := range docHeaders {
+		b.WriteString("<th>" + h + "</th>")
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, d := range docs {
+		cells := d.cells()
+		constraints := make([]string, len(d.Constraints))
+		for i, c := range d.Constraints {
+			constraints[i] = html.EscapeString(c)
+		}
+		b.WriteString("<tr>")
+		for i, c := range cells {
+			c = html.EscapeString(c)
+			if i == 3 {
+				c = strings.Join(constraints, "<br>")
+			}
+			b.WriteString("<td>" + c + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return b.String()
+}
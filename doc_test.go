@@ -0,0 +1,84 @@
+package gvalid
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/23 17:00
+ * @Desc:
+ */
+
+type docNode struct {
+	Name     string     `json:"name" valid:"required,gte=2,lt=11" name:"名称"`
+	Children []*docNode `json:"children" valid:"lte=10,dive"`
+}
+
+func TestParamDocs(t *testing.T) {
+	Convey("test param docs", t, func() {
+		docs, err := ParamDocs(&jsonGoods{})
+		So(err, ShouldBeNil)
+		So(MarkdownTable(docs), ShouldEqual, `| 参数 | 类型 | 必填 | 约束 | 说明 |
+| --- | --- | --- | --- | --- |
+| cate | integer | 是 | 取值 > 0 | 商品分类 |
+| name | string | 是 | 长度 1~10 | 商品名称 |
+| gallery | object | 是 |  | 图片 |
+| gallery.imgUrl | string | 是 |  | 图片地址 |
+| list | array[object] | 否 |  | 图片列表 |
+| list[].imgUrl | string | 是 |  | 图片地址 |
+| stock | integer | 是 | 取值 >= 1 | 库存 |
+`)
+
+		// 递归的类型不再展开
+		docs, err = ParamDocs(docNode{})
+		So(err, ShouldBeNil)
+		So(docs, ShouldHaveLength, 2)
+		So(docs[0].Constraints, ShouldResemble, []string{"长度 2~10"})
+		So(docs[1].Name, ShouldEqual, "children")
+		So(docs[1].Type, ShouldEqual, "array[object]")
+
+		RegisterEnum("OrderStatus", OrderStatusPaid, OrderStatusShipped, OrderStatusDone)
+		defer delete(enums, "OrderStatus")
+		docs, err = ParamDocs(clientForm{}, "create")
+		So(err, ShouldBeNil)
+		rows := map[string]*ParamDoc{}
+		for _, d := range docs {
+			rows[d.Name] = d
+		}
+		So(rows, ShouldNotContainKey, "Secret")
+		So(rows["id"].Constraints, ShouldResemble, []string{"必须为空或零值"})
+		So(rows["title"].Constraints, ShouldResemble, []string{"去除首尾空格", "长度 3~5"})
+		So(rows["kind"].Constraints, ShouldResemble, []string{"必须是 'a b' c 其中一个", "默认值 c"})
+		So(rows["level"].Type, ShouldEqual, "string")
+		So(rows["price"].Constraints, ShouldResemble, []string{"取值 > 0 且 < 100.5"})
+		So(rows["tags"].Constraints, ShouldResemble, []string{"不能重复", "必须是 new hot 其中一个或多个", "长度 1~2"})
+		So(rows["status"].Constraints, ShouldResemble, []string{"必须是 1 2 3 其中一个"})
+		So(rows["mobile"].Constraints, ShouldResemble, []string{"手机号码格式"})
+		So(rows["password"].Constraints, ShouldResemble, []string{"长度 >= 6"})
+		So(rows["backups[].city"].Description, ShouldEqual, "城市")
+		So(rows["remark"].Type, ShouldEqual, "string")
+
+		_, err = ParamDocs(1)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("test doc constraints", t, func() {
+		rules, err := ParseTag("gt=1,lte=2,enum=Unknown,transitions=1-2,custom=a")
+		So(err, ShouldBeNil)
+		So(DocConstraints(rules, NewFieldType(reflect.TypeOf(0))), ShouldResemble, []string{"取值 > 1 且 <= 2", "枚举 Unknown", "允许的变更 1-2", "custom a"})
+		So(DocType(NewFieldType(reflect.TypeOf([]byte{}))), ShouldEqual, "string")
+		So(DocType(NewFieldType(reflect.TypeOf([][]int{}))), ShouldEqual, "array[array]")
+		So(DocType(NewFieldType(reflect.TypeOf(map[string]int{}))), ShouldEqual, "object")
+	})
+
+	Convey("test tables escape", t, func() {
+		docs := []*ParamDoc{{Name: "a_b", Type: "string", Required: true, Constraints: []string{"匹配正则 ^a|b*$", "<= 1 <b>"}, Description: "x\ny"}}
+		So(MarkdownTable(docs), ShouldEndWith, "| a\\_b | string | 是 | 匹配正则 ^a\\|b\\*$; <= 1 &lt;b> | x y |\n")
+		So(HTMLTable(docs), ShouldContainSubstring, "<tr><td>a_b</td><td>string</td><td>是</td><td>匹配正则 ^a|b*$<br>&lt;= 1 &lt;b&gt;</td><td>x\ny</td></tr>")
+	})
+}
@@ -0,0 +1,172 @@
+package gvalid
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 15:00
+ * @Desc: 枚举
+ */
+
+// Enum 枚举类型, valid:"enum" 时调用 IsValid 验证
+type Enum interface {
+	IsValid() bool
+}
+
+var (
+	enumType = reflect.TypeOf((*Enum)(nil)).Elem()
+	enums    = make(map[string][]interface{})
+	// enumsMu 保护 enums, 验证过程中可以注册枚举
+	enumsMu sync.RWMutex
+)
+
+// RegisterEnum 注册枚举的所有值, 用于 valid:"enum=name", 错误信息中会列出所有值
+// name 与类型名相同时, valid:"enum" 的错误信息同样会列出所有值
+func RegisterEnum(name string, values ...interface{}) {
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	enums[name] = values
+}
+
+// EnumValues 已注册的枚举值
+func EnumValues(name string) (values []interface{}, ok bool) {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	values, ok = enums[name]
+	return
+}
+
+// EnumMessage 枚举类型 name 的值无效时的错误信息, 已注册时列出所有值
+func EnumMessage(name string) string {
+	if values, ok := EnumValues(name); ok {
+		return fmt.Sprintf(ValidateValNotExists, enumString(values))
+	}
+	return ValidateValEnumErr
+}
+
+// CheckEnum 验证 v 或其元素是否在 RegisterEnum 注册的 name 中, 通过时返回空字符串, 否则返回错误信息
+func CheckEnum(name string, v interface{}) string {
+	return enumError(name, reflect.ValueOf(v))
+}
+
+// enumError 验证枚举, name 为空时使用 Enum 接口, 通过时返回空字符串
+func enumError(name string, vOf reflect.Value) string {
+	vOf = indirect(vOf)
+	if !vOf.IsValid() {
+		return ""
+	}
+	elems := []reflect.Value{vOf}
+	if vOf.Kind() == reflect.Slice || vOf.Kind() == reflect.Array {
+		elems = elems[:0]
+		for i := 0; i < vOf.Len(); i++ {
+			elems = append(elems, indirect(vOf.Index(i)))
+		}
+	}
+
+	values, registered := EnumValues(name)
+	if name != "" && !registered {
+		return ValidateValTypeErr
+	}
+	for _, elem := range elems {
+		if !elem.IsValid() {
+			continue
+		}
+		if name != "" {
+			if !enumContains(values, elem) {
+				return fmt.Sprintf(ValidateValNotExists, enumString(values))
+			}
+			continue
+		}
+
+		e, ok := asEnum(elem)
+		if !ok {
+			return fmt.Sprintf(ValidateMethodNotAllowSth, "enum", elem.Type().String())
+		}
+		if !e.IsValid() {
+			return EnumMessage(elem.Type().Name())
+		}
+	}
+	return ""
+}
+
+// enumContains values 中是否包含 vOf, 整数, 浮点数, 字符串按值比较, 不要求类型相同
+func enumContains(values []interface{}, vOf reflect.Value) bool {
+	for _, v := range values {
+		if enumEqual(reflect.ValueOf(v), vOf) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumEqual(a, b reflect.Value) bool {
+	switch {
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()):
+		return a.Int() == b.Int()
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		return a.Uint() == b.Uint()
+	case isIntKind(a.Kind()) && isUintKind(b.Kind()):
+		return a.Int() >= 0 && uint64(a.Int()) == b.Uint()
+	case isUintKind(a.Kind()) && isIntKind(b.Kind()):
+		return b.Int() >= 0 && a.Uint() == uint64(b.Int())
+	case isFloatKind(a.Kind()) && isFloatKind(b.Kind()):
+		return a.Float() == b.Float()
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return a.String() == b.String()
+	default:
+		return a.CanInterface() && b.CanInterface() && reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+// enumString 枚举值列表, 用于错误信息
+func enumString(values []interface{}) string {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		rv := reflect.ValueOf(v)
+		switch {
+		case isIntKind(rv.Kind()):
+			s = append(s, fmt.Sprint(rv.Int()))
+		case isUintKind(rv.Kind()):
+			s = append(s, fmt.Sprint(rv.Uint()))
+		case rv.Kind() == reflect.String:
+			s = append(s, rv.String())
+		default:
+			s = append(s, fmt.Sprint(v))
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+// asEnum 值或指针实现了 Enum
+func asEnum(vOf reflect.Value) (Enum, bool) {
+	if !vOf.CanInterface() {
+		return nil, false
+	}
+	if vOf.Type().Implements(enumType) {
+		return vOf.Interface().(Enum), true
+	}
+	if reflect.PtrTo(vOf.Type()).Implements(enumType) {
+		ptr := reflect.New(vOf.Type())
+		ptr.Elem().Set(vOf)
+		return ptr.Interface().(Enum), true
+	}
+	return nil, false
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
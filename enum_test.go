@@ -0,0 +1,112 @@
+package gvalid
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 15:30
+ * @Desc:
+ */
+
+// OrderStatus 订单状态
+type OrderStatus int
+
+const (
+	OrderStatusPaid OrderStatus = iota + 1
+	OrderStatusShipped
+	OrderStatusDone
+)
+
+func (s OrderStatus) IsValid() bool {
+	return s >= OrderStatusPaid && s <= OrderStatusDone
+}
+
+// PayType 支付方式, 指针实现 Enum
+type PayType string
+
+func (p *PayType) IsValid() bool {
+	return *p == "alipay" || *p == "wechat"
+}
+
+func TestEnum(t *testing.T) {
+	type Order struct {
+		Status  OrderStatus   `valid:"enum" name:"状态"`
+		Pay     PayType       `valid:"enum" name:"支付方式"`
+		History []OrderStatus `valid:"enum=OrderStatus" name:"历史状态"`
+		Source  string        `valid:"enum=OrderSource" name:"来源"`
+		Name    string        `valid:"enum" name:"名称"`
+	}
+
+	RegisterEnum("OrderSource", "app", "web")
+	defer delete(enums, "OrderSource")
+
+	Convey("test enum interface", t, func() {
+		v := &Validation{}
+		b, err := v.Valid(&Order{Status: OrderStatusDone, Pay: "alipay"})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+
+		v = &Validation{}
+		_, _ = v.Valid(&Order{Status: 9, Pay: "cash"})
+		So(errorPaths(v), ShouldResemble, []string{"Status", "Pay"})
+		So(v.Errors[0].Message, ShouldEqual, ValidateValEnumErr)
+		So(v.Errors[0].Rule, ShouldEqual, "enum")
+
+		v = &Validation{}
+		_, _ = v.Valid(&Order{Name: "x"})
+		So(errorPaths(v), ShouldResemble, []string{"Name"})
+	})
+
+	Convey("test enum registry", t, func() {
+		RegisterEnum("OrderStatus", OrderStatusPaid, OrderStatusShipped, OrderStatusDone)
+		defer delete(enums, "OrderStatus")
+
+		v := &Validation{}
+		b, _ := v.Valid(&Order{History: []OrderStatus{1, 2}, Source: "app"})
+		So(b, ShouldBeTrue)
+
+		v = &Validation{}
+		_, _ = v.Valid(&Order{Status: 9, History: []OrderStatus{1, 4}, Source: "pc"})
+		So(errorPaths(v), ShouldResemble, []string{"Status", "History", "Source"})
+		So(v.Errors[0].Message, ShouldEqual, "必须是 1 2 3 其中一个")
+		So(v.Errors[2].Message, ShouldEqual, "必须是 app web 其中一个")
+
+		values, ok := EnumValues("OrderSource")
+		So(ok, ShouldBeTrue)
+		So(values, ShouldResemble, []interface{}{"app", "web"})
+	})
+
+	Convey("test enum not registered", t, func() {
+		type Bad struct {
+			Kind int `valid:"enum=NotExists" name:"类型"`
+		}
+		v := &Validation{}
+		_, _ = v.Valid(&Bad{Kind: 1})
+		So(v.Errors[0].Message, ShouldEqual, ValidateValTypeErr)
+	})
+
+	Convey("test concurrent registration", t, func() {
+		defer delete(enums, "OrderStatus")
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				RegisterEnum("OrderStatus", OrderStatusPaid, OrderStatusShipped, OrderStatusDone)
+			}()
+			go func() {
+				defer wg.Done()
+				_, _ = (&Validation{}).Valid(&Order{History: []OrderStatus{1}, Source: "app"})
+			}()
+		}
+		wg.Wait()
+		_, ok := EnumValues("OrderStatus")
+		So(ok, ShouldBeTrue)
+	})
+}
@@ -1,6 +1,11 @@
 package gvalid
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
 
 /**
  * @Author: BoolDesign
@@ -12,6 +17,20 @@ import "fmt"
 // Error ...
 type Error struct {
 	Field, Name, Message string
+	// Path 字段完整路径, 如 Address[0].City
+	Path string
+	// Rule 未通过的验证规则, 如 gte, 自定义验证为 custom
+	Rule string
+	// Param 验证规则的参数, 如 gte=10 中的 10, 多个参数时使用空格拼接
+	Param string
+	// Params 验证规则解析后的参数, 如 in='a b',c 中的 [a b, c]
+	Params []string
+	// Kind 字段类型, 如 int, string, slice
+	Kind string
+	// Value 未通过验证的字段值, 指针取其指向的值
+	Value interface{}
+	// Code 错误码, 默认由 Rule 生成, 可通过 SetErrorCode 自定义
+	Code string
 }
 
 // String Return Message
@@ -21,3 +40,35 @@ func (e *Error) String() string {
 	}
 	return fmt.Sprintf("%s %s", e.Name, e.Message)
 }
+
+var (
+	errorCodes = map[string]string{}
+	// errorCodesMu 保护 errorCodes, 验证过程中可以设置错误码
+	errorCodesMu sync.RWMutex
+)
+
+// SetErrorCode 自定义验证规则的错误码
+func SetErrorCode(rule, code string) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+	errorCodes[rule] = code
+}
+
+// ErrorCode 验证规则的错误码, 默认为 ERR_ 加规则名的大写下划线形式, 如 idCard => ERR_ID_CARD
+func ErrorCode(rule string) string {
+	errorCodesMu.RLock()
+	code, ok := errorCodes[rule]
+	errorCodesMu.RUnlock()
+	if ok {
+		return code
+	}
+	var b strings.Builder
+	b.WriteString("ERR_")
+	for i, r := range rule {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,138 @@
+package gvalid
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 16:00
+ * @Desc: gvalid-gen 生成的验证代码使用
+ */
+
+// Errors 验证错误, gvalid-gen 生成的 Validate 方法返回
+type Errors []*Error
+
+func (errs Errors) Error() string {
+	s := make([]string, len(errs))
+	for i, err := range errs {
+		s[i] = err.String()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Generated gvalid-gen 生成的验证代码使用, 记录当前路径, 验证场景和错误
+type Generated struct {
+	// Groups 当前验证场景, 同 Validation.Groups
+	Groups []string
+	Errors Errors
+
+	scope Scope
+}
+
+// Scope 当前结构体所在的路径, 及是否通过未导出的字段访问, 同 reflect 的 CanInterface
+type Scope struct {
+	path string
+	// hidden 通过未导出的字段访问, Error 不包含 Value, 不执行结构体级别的验证
+	hidden bool
+	// opaque 匿名嵌入的未导出结构体, 不执行结构体级别的验证, 其导出的字段仍可访问
+	opaque bool
+}
+
+// Enter 进入嵌套结构体, 如 Address, Address[0], 匿名嵌入时 field 为空, 返回之前的 Scope
+func (g *Generated) Enter(field string, exported bool) (prev Scope) {
+	prev = g.scope
+	g.scope = Scope{path: joinPath(prev.path, field), hidden: prev.hidden}
+	if !exported {
+		if field == "" {
+			g.scope.opaque = true
+		} else {
+			g.scope.hidden = true
+		}
+	}
+	return
+}
+
+// Leave 返回之前的 Scope
+func (g *Generated) Leave(prev Scope) {
+	g.scope = prev
+}
+
+// InGroups 规则是否属于当前验证场景
+func (g *Generated) InGroups(groups ...string) bool {
+	return (&Validation{Groups: g.Groups}).inGroups(groups)
+}
+
+// Fail 添加 Error, 与 Validation 设置的 Error 一致
+// err.Value 为字段值, 指针取其指向的值; masker 为 tag 中 sensitive 的脱敏方式, 为空时按 SetSensitiveField 设置的字段名
+func (g *Generated) Fail(err *Error, masker string) {
+	err.Path = joinPath(g.scope.path, err.Field)
+	if g.scope.hidden {
+		err.Value = nil
+	}
+	err.Value = g.Display(err.Field, masker, err.Value)
+	if err.Code == "" {
+		err.Code = ErrorCode(err.Rule)
+	}
+	g.Errors = append(g.Errors, err)
+}
+
+// Display 字段值 v 用于错误信息的形式, 与 Fail 的脱敏方式一致
+func (g *Generated) Display(field, masker string, v interface{}) interface{} {
+	if masker == "" {
+		masker, _ = sensitiveField(field)
+	}
+	if m, ok := maskerOf(masker); ok {
+		return maskedValue(v, m)
+	}
+	return v
+}
+
+// Struct 执行结构体级别的验证, RegisterStructValidation 注册的验证, ValidCustom, ValidCustomCtx
+// obj 为结构体指针; embeddedIn 为匿名嵌入时外层结构体的指针, 方法已提升到外层的由外层执行
+func (g *Generated) Struct(obj, embeddedIn interface{}) (err error) {
+	if g.scope.hidden || g.scope.opaque {
+		return
+	}
+	var valid *Validation
+	validation := func() *Validation {
+		if valid == nil {
+			valid = &Validation{Groups: g.Groups, path: g.scope.path}
+		}
+		return valid
+	}
+	defer func() {
+		if valid != nil {
+			g.Errors = append(g.Errors, valid.Errors...)
+		}
+	}()
+
+	if fns := structValidationsOf(reflect.TypeOf(obj).Elem()); len(fns) > 0 {
+		v := reflect.ValueOf(obj).Elem().Interface()
+		for _, fn := range fns {
+			fn(validation(), v)
+		}
+	}
+	if form, ok := obj.(ValidCustom); ok {
+		if _, promoted := embeddedIn.(ValidCustom); !promoted {
+			form.Valid(validation())
+		}
+	}
+	if form, ok := obj.(ValidCustomCtx); ok {
+		if _, promoted := embeddedIn.(ValidCustomCtx); !promoted {
+			return form.ValidCtx(context.Background(), validation(), g.scope.path)
+		}
+	}
+	return
+}
+
+// Err 没有错误时返回 nil, 否则返回 Errors
+func (g *Generated) Err() error {
+	if len(g.Errors) == 0 {
+		return nil
+	}
+	return g.Errors
+}
@@ -0,0 +1,141 @@
+package gvalid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/24 17:00
+ * @Desc: 泛型 API, 规则的参数与字段类型在编译时检查
+ */
+
+// Integer 规则支持的整数类型, 同 tag 中 gt, in 等规则支持的 int8, int32, int, int64
+// int16 及无符号整数不支持, 在编译时报错, 而不是验证时返回 不支持该类型
+type Integer interface {
+	~int | ~int8 | ~int32 | ~int64
+}
+
+// Float 浮点数类型
+type Float interface {
+	~float32 | ~float64
+}
+
+// Ordered 可比较大小的类型
+type Ordered interface {
+	Integer | Float | ~string
+}
+
+// Validate 验证 v, v 为结构体或结构体指针, 未通过验证时返回 Errors, 验证过程出错时返回该错误
+func Validate[T any](v T, groups ...string) error {
+	valid := &Validation{Groups: groups}
+	if _, err := valid.Valid(v); err != nil {
+		return err
+	}
+	if valid.HasErrors() {
+		return Errors(valid.Errors)
+	}
+	return nil
+}
+
+// TypedRule 类型为 T 的字段的规则
+type TypedRule[T any] struct {
+	rules RuleSet
+}
+
+// Min 最小值, 数字同 gte; 字符串按字典序比较, 同 strGte, 如 Min("2024-01-01"), 字符串, slice 的长度使用 MinLen
+func Min[T Ordered](n T) TypedRule[T] {
+	if s, ok := stringOf(n); ok {
+		return TypedRule[T]{rules: RuleSet{StrGte(s)}}
+	}
+	return TypedRule[T]{rules: RuleSet{newRule("gte", fmt.Sprint(n))}}
+}
+
+// Max 最大值, 数字同 lte; 字符串按字典序比较, 同 strLte, 字符串, slice 的长度使用 MaxLen
+func Max[T Ordered](n T) TypedRule[T] {
+	if s, ok := stringOf(n); ok {
+		return TypedRule[T]{rules: RuleSet{StrLte(s)}}
+	}
+	return TypedRule[T]{rules: RuleSet{newRule("lte", fmt.Sprint(n))}}
+}
+
+// stringOf 底层类型为 string 时返回其值
+func stringOf[T any](n T) (string, bool) {
+	vOf := reflect.ValueOf(n)
+	if vOf.Kind() != reflect.String {
+		return "", false
+	}
+	return vOf.String(), true
+}
+
+// OneOf 必须是 values 其中一个, 同 in
+func OneOf[T Integer | ~string](values ...T) TypedRule[T] {
+	vs := make([]interface{}, len(values))
+	for i, v := range values {
+		vs[i] = v
+	}
+	return TypedRule[T]{rules: RuleSet{In(vs...)}}
+}
+
+// Any 将没有类型的规则用于类型为 T 的字段, 如 Any[string](Required(), MaxLen(10))
+// 规则与字段类型是否匹配在 RulesFor 时检查
+func Any[T any](rules ...Rule) TypedRule[T] {
+	return TypedRule[T]{rules: rules}
+}
+
+// On 规则所属分组, 同 tag 中的 @create
+func (tr TypedRule[T]) On(groups ...string) TypedRule[T] {
+	rules := make(RuleSet, len(tr.rules))
+	for i, r := range tr.rules {
+		rules[i] = r.On(groups...)
+	}
+	return TypedRule[T]{rules: rules}
+}
+
+// TypedField 结构体 S 中一个字段的规则, 由 FieldOf 生成
+type TypedField[S any] struct {
+	field func(s *S) *FieldRules
+}
+
+// FieldOf 字段的规则, get 返回字段的指针, 如 func(u *User) *string { return &u.Name }
+// 规则的类型须与字段类型一致, 如 int 字段不能使用 Min(1.5)
+func FieldOf[S, T any](get func(s *S) *T, rules ...TypedRule[T]) TypedField[S] {
+	return TypedField[S]{field: func(s *S) *FieldRules {
+		var rs []Rule
+		for _, r := range rules {
+			rs = append(rs, r.rules...)
+		}
+		return Field(get(s), rs...)
+	}}
+}
+
+// Label 字段名称, 同 name tag, 用于错误信息
+func (tf TypedField[S]) Label(name string) TypedField[S] {
+	field := tf.field
+	return TypedField[S]{field: func(s *S) *FieldRules {
+		return field(s).Label(name)
+	}}
+}
+
+// RulesFor 为结构体 S 注册字段规则, 同 Rules
+func RulesFor[S any](fields ...TypedField[S]) error {
+	s := new(S)
+	frs := make([]*FieldRules, len(fields))
+	for i, f := range fields {
+		if f.field == nil {
+			return errors.New("TypedField 必须由 FieldOf 生成")
+		}
+		frs[i] = f.field(s)
+	}
+	return Rules(s, frs...)
+}
+
+// MustRulesFor 同 RulesFor, 有错误时 panic
+func MustRulesFor[S any](fields ...TypedField[S]) {
+	if err := RulesFor(fields...); err != nil {
+		panic(err)
+	}
+}
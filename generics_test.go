@@ -0,0 +1,105 @@
+package gvalid
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/24 17:40
+ * @Desc:
+ */
+
+type typedStatus int
+
+type typedUser struct {
+	Name   string `name:"姓名"`
+	Age    int
+	Score  float64 `valid:"required" name:"分数"`
+	Status typedStatus
+	Email  string
+	Since  string `name:"开始日期"`
+}
+
+func init() {
+	MustRulesFor(
+		FieldOf(func(u *typedUser) *string { return &u.Name }, Any[string](Required(), MaxLen(10))),
+		FieldOf(func(u *typedUser) *int { return &u.Age }, Min(18), Max(60).On("create")).Label("年龄"),
+		FieldOf(func(u *typedUser) *float64 { return &u.Score }, Max(99.5)),
+		FieldOf(func(u *typedUser) *typedStatus { return &u.Status }, OneOf[typedStatus](1, 2)).Label("状态"),
+		FieldOf(func(u *typedUser) *string { return &u.Since }, Min("2020-01-01"), Max("2029-12-31").On("create")),
+	)
+}
+
+func TestGenerics(t *testing.T) {
+	Convey("Validate", t, func() {
+		So(Validate(typedUser{Name: "wei", Age: 20, Score: 60}), ShouldBeNil)
+		So(Validate(&typedUser{Name: "wei", Age: 70, Score: 60}), ShouldBeNil)
+
+		err := Validate(&typedUser{Name: "abcdefghijk", Age: 70, Score: 99.9, Status: 3, Since: "2030-01-01"}, "create")
+		var errs Errors
+		So(errors.As(err, &errs), ShouldBeTrue)
+		So(messages(errs), ShouldResemble, []string{
+			"Name lte 姓名 长度必须是小于等于 10",
+			"Age lte 年龄 必须是小于等于 60",
+			"Score lte 分数 必须是小于等于 99.50",
+			"Status in 状态 必须是 1 2 其中一个",
+			"Since strLte 开始日期 必须是小于等于 2029-12-31",
+		})
+		So(Validate(&typedUser{Name: "wei", Age: 20, Score: 60, Since: "2030-01-01"}), ShouldBeNil)
+
+		err = Validate(&typedUser{Name: "wei", Age: 20, Score: 60, Since: "2019-12-31"})
+		So(errors.As(err, &errs), ShouldBeTrue)
+		So(messages(errs), ShouldResemble, []string{"Since strGte 开始日期 必须是大于等于 2020-01-01"})
+		So(errs[0].Param, ShouldEqual, "2020-01-01")
+
+		// tag 中的规则仍然生效
+		err = Validate(&typedUser{Name: "wei", Age: 20})
+		So(errors.As(err, &errs), ShouldBeTrue)
+		So(errs[0].Path, ShouldEqual, "Score")
+		So(errs[0].Rule, ShouldEqual, "required")
+
+		So(Validate(1), ShouldNotBeNil)
+	})
+
+	Convey("string bounds in RuleSet", t, func() {
+		ts, err := DescribeType(reflect.TypeOf(typedUser{}))
+		So(err, ShouldBeNil)
+		rules := ts.Fields[5].Rules
+		So(rules, ShouldHaveLength, 2)
+		So(rules[0].Name, ShouldEqual, "strGte")
+		So(rules[0].Params, ShouldResemble, []Param{{Value: "2020-01-01", Type: ParamString}})
+		So(rules[1].Name, ShouldEqual, "strLte")
+		So(rules[1].Groups, ShouldResemble, []string{"create"})
+
+		desc, err := ClientRules(typedUser{}, "create")
+		So(err, ShouldBeNil)
+		errs, err := desc.Evaluate([]byte(`{"Name": "wei", "Score": 60, "Since": "2019-12-31"}`))
+		So(err, ShouldBeNil)
+		So(messages(errs), ShouldResemble, []string{"Since strGte 开始日期 必须是大于等于 2020-01-01"})
+		errs, err = desc.Evaluate([]byte(`{"Name": "wei", "Score": 60, "Since": "2030-01-01"}`))
+		So(err, ShouldBeNil)
+		So(messages(errs), ShouldResemble, []string{"Since strLte 开始日期 必须是小于等于 2029-12-31"})
+
+		So(CheckRule(StrGte("a"), &FieldType{Kind: reflect.Int, Type: "int"}), ShouldNotBeNil)
+	})
+
+	Convey("RulesFor errors", t, func() {
+		err := RulesFor(
+			FieldOf(func(u *typedUser) *string { return &u.Email }, Any[string](Gt(1.5))),
+			FieldOf(func(u *typedUser) *int { return new(int) }, Min(1)),
+		)
+		var errs CompileErrors
+		So(errors.As(err, &errs), ShouldBeTrue)
+		So(errorStrings(errs), ShouldResemble, []string{
+			"typedUser.Email: gt: gt 的参数 1.5 应为整数",
+			"typedUser: *int 不是 gvalid.typedUser 的字段",
+		})
+		So(RulesFor(TypedField[typedUser]{}), ShouldNotBeNil)
+	})
+}
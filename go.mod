@@ -1,5 +1,16 @@
 module github.com/booldesign/gvalid
 
-go 1.16
+go 1.22.0
 
-require github.com/smartystreets/goconvey v1.7.2
+require (
+	github.com/smartystreets/goconvey v1.7.2
+	golang.org/x/tools v0.30.0
+)
+
+require (
+	github.com/gopherjs/gopherjs v0.0.0-20181017120253-0766667cb4d1 // indirect
+	github.com/jtolds/gls v4.20.0+incompatible // indirect
+	github.com/smartystreets/assertions v1.2.0 // indirect
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+)
@@ -0,0 +1,66 @@
+package gvalid
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/19 17:10
+ * @Desc:
+ */
+
+type groupAddress struct {
+	City string `valid:"required@create" name:"市"`
+}
+
+type groupUser struct {
+	Id       int64         `valid:"empty@create,required@update|admin,gt=0" name:"编号"`
+	Name     string        `valid:"required,lte=10" name:"姓名"`
+	Password string        `valid:"required@register,gte=8" name:"密码"`
+	Mobile   string        `valid:"required,regex=(/^1\\d{10}$/)@create" name:"手机"`
+	Remark   string        `valid:"required,lte=5" groups:"admin" name:"备注"`
+	Address  *groupAddress `valid:"dive" name:"地址"`
+}
+
+func TestGroups(t *testing.T) {
+	Convey("test groups", t, func() {
+		u := &groupUser{Name: "wei", Mobile: "123", Address: &groupAddress{}}
+
+		v := &Validation{}
+		b, err := v.Valid(u)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+
+		v = &Validation{Groups: []string{"create"}}
+		_, _ = v.Valid(u)
+		So(errorPaths(v), ShouldResemble, []string{"Mobile", "Address.City"})
+
+		u.Id = 1
+		v = &Validation{Groups: []string{"create", "register"}}
+		_, _ = v.Valid(u)
+		So(errorPaths(v), ShouldResemble, []string{"Id", "Password", "Mobile", "Address.City"})
+
+		u.Id = 0
+		v = &Validation{Groups: []string{"update"}}
+		_, _ = v.Valid(u)
+		So(errorPaths(v), ShouldResemble, []string{"Id"})
+
+		v = &Validation{Groups: []string{"admin"}}
+		_, _ = v.Valid(u)
+		So(errorPaths(v), ShouldResemble, []string{"Id", "Remark"})
+	})
+
+	Convey("test parse groups", t, func() {
+		vfs, err := parseTag("in=a@b.com")
+		So(err, ShouldBeNil)
+		So(vfs, ShouldResemble, []ValidFunc{{Name: "RuleIn", Params: "'a@b.com'", Values: []string{"a@b.com"}}})
+
+		vfs, err = parseTag("required@create|update")
+		So(err, ShouldBeNil)
+		So(vfs, ShouldResemble, []ValidFunc{{Name: "RuleRequired", Params: "", Groups: []string{"create", "update"}}})
+	})
+}
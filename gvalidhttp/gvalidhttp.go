@@ -0,0 +1,222 @@
+package gvalidhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/booldesign/gvalid"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/19 10:12
+ * @Desc: net/http 集成, 解析请求体并验证, 验证失败时输出统一的 JSON 错误响应
+ */
+
+const (
+	ContentTypeJSON    = "application/json; charset=utf-8"
+	ContentTypeProblem = "application/problem+json; charset=utf-8"
+)
+
+const (
+	// DecodeRule 请求体解析失败时 FieldError.Rule 的值
+	DecodeRule = "decode"
+	// InternalRule 验证过程出错 (如 tag 格式错误) 时 FieldError.Rule 的值
+	InternalRule = "internal"
+)
+
+const (
+	// DefaultMaxBodyBytes Binder.MaxBodyBytes 为 0 时请求体的最大字节数
+	DefaultMaxBodyBytes = 1 << 20
+	// InternalMessage 验证过程出错时响应中的错误信息, 具体的错误交给 Binder.OnError
+	InternalMessage = "服务器内部错误"
+)
+
+// FieldError 单个字段错误
+type FieldError struct {
+	Path  string `json:"path"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+	// Params 解析后的参数, 如 in='a b',c 为 ["a b","c"]
+	Params  []string `json:"params,omitempty"`
+	Code    string   `json:"code,omitempty"`
+	Message string   `json:"message"`
+}
+
+// Response 默认错误响应
+type Response struct {
+	Status int           `json:"status"`
+	Errors []*FieldError `json:"errors"`
+}
+
+// Problem RFC 7807 错误响应
+type Problem struct {
+	Type     string        `json:"type"`
+	Title    string        `json:"title"`
+	Status   int           `json:"status"`
+	Detail   string        `json:"detail,omitempty"`
+	Instance string        `json:"instance,omitempty"`
+	Errors   []*FieldError `json:"errors"`
+}
+
+// RenderFunc 自定义错误响应
+type RenderFunc func(w http.ResponseWriter, r *http.Request, status int, errs []*FieldError)
+
+// Binder 解析并验证请求
+type Binder struct {
+	// Problem 使用 application/problem+json (RFC 7807) 输出错误
+	Problem bool
+	// Render 自定义错误响应, 设置后 Problem 不生效
+	Render RenderFunc
+	// DisallowUnknownFields 请求体中含有结构体不存在的字段时返回错误
+	DisallowUnknownFields bool
+	// MaxBodyBytes 请求体的最大字节数, 超过时返回 413, 0 为 DefaultMaxBodyBytes, 小于 0 不限制
+	MaxBodyBytes int64
+	// OnError 验证过程出错 (如 tag 格式错误) 时调用, 用于记录日志, 为 nil 时使用 log.Printf
+	// 错误可能包含结构体及 tag 等内部信息, 响应中只返回 InternalMessage
+	OnError func(r *http.Request, err error)
+}
+
+// Default 默认 Binder
+var Default = &Binder{}
+
+type ctxKey struct{}
+
+// Bind 使用 Default 解析并验证请求
+func Bind(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	return Default.Bind(w, r, dst)
+}
+
+// Middleware 使用 Default 生成中间件
+func Middleware(newDst func() interface{}) func(http.Handler) http.Handler {
+	return Default.Middleware(newDst)
+}
+
+// FromContext 获取中间件解析后的请求对象
+func FromContext(ctx context.Context) interface{} {
+	return ctx.Value(ctxKey{})
+}
+
+// Bind 解析 JSON 请求体到 dst 并验证
+// 字段类型错误与验证错误一起以 422 返回, JSON 格式错误及请求体不是对象返回 400, 请求体过大返回 413
+// 失败时已输出错误响应, 返回 false, 调用方直接 return 即可
+func (b *Binder) Bind(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	body := r.Body
+	if limit := b.maxBodyBytes(); limit > 0 {
+		body = http.MaxBytesReader(w, r.Body, limit)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		var me *http.MaxBytesError
+		if errors.As(err, &me) {
+			b.Error(w, r, http.StatusRequestEntityTooLarge, []*FieldError{{Rule: DecodeRule, Message: fmt.Sprintf("请求体超过 %d 字节", me.Limit)}})
+			return false
+		}
+		b.Error(w, r, http.StatusBadRequest, []*FieldError{{Rule: DecodeRule, Message: err.Error()}})
+		return false
+	}
+
+	v := &gvalid.Validation{DisallowUnknownFields: b.DisallowUnknownFields}
+	ok, err := v.ValidateJSON(data, dst)
+	if err != nil {
+		if isDecodeError(err) {
+			b.Error(w, r, http.StatusBadRequest, []*FieldError{{Rule: DecodeRule, Message: err.Error()}})
+		} else {
+			b.internalError(r, err)
+			b.Error(w, r, http.StatusInternalServerError, []*FieldError{{Rule: InternalRule, Message: InternalMessage}})
+		}
+		return false
+	}
+	if !ok {
+		b.Error(w, r, http.StatusUnprocessableEntity, FieldErrors(v.Errors))
+		return false
+	}
+	return true
+}
+
+func (b *Binder) maxBodyBytes() int64 {
+	if b.MaxBodyBytes == 0 {
+		return DefaultMaxBodyBytes
+	}
+	return b.MaxBodyBytes
+}
+
+// internalError 验证过程出错, 交给 OnError 或写入日志
+func (b *Binder) internalError(r *http.Request, err error) {
+	if b.OnError != nil {
+		b.OnError(r, err)
+		return
+	}
+	log.Printf("gvalidhttp: %s %s: %v", r.Method, r.URL.Path, err)
+}
+
+// Middleware 解析并验证请求后调用 next, 请求对象通过 FromContext 获取
+// newDst 每次请求返回一个新的结构体指针
+func (b *Binder) Middleware(newDst func() interface{}) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dst := newDst()
+			if !b.Bind(w, r, dst) {
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey{}, dst)))
+		})
+	}
+}
+
+// Error 输出错误响应
+func (b *Binder) Error(w http.ResponseWriter, r *http.Request, status int, errs []*FieldError) {
+	if b.Render != nil {
+		b.Render(w, r, status, errs)
+		return
+	}
+
+	if b.Problem {
+		writeJSON(w, status, ContentTypeProblem, &Problem{
+			Type:     "about:blank",
+			Title:    http.StatusText(status),
+			Status:   status,
+			Detail:   detail(errs),
+			Instance: r.URL.Path,
+			Errors:   errs,
+		})
+		return
+	}
+	writeJSON(w, status, ContentTypeJSON, &Response{Status: status, Errors: errs})
+}
+
+// FieldErrors 转换 gvalid.Error
+func FieldErrors(errs []*gvalid.Error) []*FieldError {
+	fes := make([]*FieldError, 0, len(errs))
+	for _, e := range errs {
+		fes = append(fes, &FieldError{Path: e.Path, Rule: e.Rule, Param: e.Param, Params: e.Params, Code: e.Code, Message: e.String()})
+	}
+	return fes
+}
+
+// isDecodeError 是否是请求体格式错误, ValidateJSON 只对根的类型错误返回 *json.UnmarshalTypeError
+func isDecodeError(err error) bool {
+	var se *json.SyntaxError
+	var te *json.UnmarshalTypeError
+	return errors.As(err, &se) || errors.As(err, &te) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// detail 取第一个错误作为 Problem.Detail
+func detail(errs []*FieldError) string {
+	if len(errs) == 0 {
+		return ""
+	}
+	return errs[0].Message
+}
+
+func writeJSON(w http.ResponseWriter, status int, contentType string, body interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,180 @@
+package gvalidhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/booldesign/gvalid"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/19 10:40
+ * @Desc:
+ */
+
+type Address struct {
+	City string `json:"city" valid:"required" name:"市"`
+}
+
+type UserForm struct {
+	Name    string     `json:"name" valid:"required,lte=10" name:"姓名"`
+	Age     int        `json:"age" valid:"gte=18" name:"年龄"`
+	Address []*Address `json:"address" valid:"dive" name:"地址"`
+}
+
+func handler(b *Binder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		form := &UserForm{}
+		if !b.Bind(w, r, form) {
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func serve(h http.Handler, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body)))
+	return rec
+}
+
+func TestBind(t *testing.T) {
+	Convey("valid request", t, func() {
+		rec := serve(handler(&Binder{}), `{"name":"wei","age":18,"address":[{"city":"上海"}]}`)
+		So(rec.Code, ShouldEqual, http.StatusOK)
+	})
+
+	Convey("invalid request", t, func() {
+		rec := serve(handler(&Binder{}), `{"name":"","age":10,"address":[{"city":""}]}`)
+		So(rec.Code, ShouldEqual, http.StatusUnprocessableEntity)
+		So(rec.Header().Get("Content-Type"), ShouldEqual, ContentTypeJSON)
+
+		resp := &Response{}
+		So(json.Unmarshal(rec.Body.Bytes(), resp), ShouldBeNil)
+		So(resp.Status, ShouldEqual, http.StatusUnprocessableEntity)
+		So(resp.Errors, ShouldResemble, []*FieldError{
+			{Path: "Name", Rule: "required", Code: "ERR_REQUIRED", Message: "姓名 不能为空或零值"},
+			{Path: "Age", Rule: "gte", Param: "18", Params: []string{"18"}, Code: "ERR_GTE", Message: "年龄 必须是大于等于 18"},
+			{Path: "Address[0].City", Rule: "required", Code: "ERR_REQUIRED", Message: "市 不能为空或零值"},
+		})
+	})
+
+	Convey("type error and unknown field", t, func() {
+		rec := serve(handler(&Binder{DisallowUnknownFields: true}), `{"name":"wei","age":"18","sex":1}`)
+		So(rec.Code, ShouldEqual, http.StatusUnprocessableEntity)
+
+		resp := &Response{}
+		So(json.Unmarshal(rec.Body.Bytes(), resp), ShouldBeNil)
+		So(len(resp.Errors), ShouldEqual, 2)
+		for _, e := range resp.Errors {
+			switch e.Path {
+			case "Age":
+				So(e.Rule, ShouldEqual, gvalid.TypeRule)
+			default:
+				So(e.Path, ShouldEqual, "sex")
+				So(e.Rule, ShouldEqual, gvalid.UnknownRule)
+			}
+		}
+	})
+
+	Convey("malformed body", t, func() {
+		rec := serve(handler(&Binder{}), `{"name":`)
+		So(rec.Code, ShouldEqual, http.StatusBadRequest)
+
+		resp := &Response{}
+		So(json.Unmarshal(rec.Body.Bytes(), resp), ShouldBeNil)
+		So(resp.Errors[0].Rule, ShouldEqual, DecodeRule)
+
+		rec = serve(handler(&Binder{}), `[]`)
+		So(rec.Code, ShouldEqual, http.StatusBadRequest)
+		So(json.Unmarshal(rec.Body.Bytes(), resp), ShouldBeNil)
+		So(resp.Errors[0].Rule, ShouldEqual, DecodeRule)
+	})
+
+	Convey("body too large", t, func() {
+		body := `{"name":"wei","age":18}`
+		rec := serve(handler(&Binder{MaxBodyBytes: int64(len(body) - 1)}), body)
+		So(rec.Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+
+		resp := &Response{}
+		So(json.Unmarshal(rec.Body.Bytes(), resp), ShouldBeNil)
+		So(resp.Errors[0].Rule, ShouldEqual, DecodeRule)
+
+		rec = serve(handler(&Binder{MaxBodyBytes: -1}), `{"name":"`+strings.Repeat("a", DefaultMaxBodyBytes)+`"}`)
+		So(rec.Code, ShouldEqual, http.StatusUnprocessableEntity)
+	})
+
+	Convey("internal error", t, func() {
+		type BadForm struct {
+			Name string `json:"name" valid:"in='a"`
+		}
+		var internal error
+		b := &Binder{OnError: func(r *http.Request, err error) { internal = err }}
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b.Bind(w, r, &BadForm{})
+		})
+		rec := serve(h, `{"name":"a"}`)
+		So(rec.Code, ShouldEqual, http.StatusInternalServerError)
+		So(internal, ShouldNotBeNil)
+
+		// 内部错误不写入响应
+		resp := &Response{}
+		So(json.Unmarshal(rec.Body.Bytes(), resp), ShouldBeNil)
+		So(resp.Errors, ShouldResemble, []*FieldError{{Rule: InternalRule, Message: InternalMessage}})
+		So(rec.Body.String(), ShouldNotContainSubstring, "BadForm")
+	})
+}
+
+func TestProblem(t *testing.T) {
+	Convey("problem+json", t, func() {
+		rec := serve(handler(&Binder{Problem: true}), `{"name":"wei","age":1}`)
+		So(rec.Code, ShouldEqual, http.StatusUnprocessableEntity)
+		So(rec.Header().Get("Content-Type"), ShouldEqual, ContentTypeProblem)
+
+		p := &Problem{}
+		So(json.Unmarshal(rec.Body.Bytes(), p), ShouldBeNil)
+		So(p.Type, ShouldEqual, "about:blank")
+		So(p.Title, ShouldEqual, "Unprocessable Entity")
+		So(p.Status, ShouldEqual, http.StatusUnprocessableEntity)
+		So(p.Detail, ShouldEqual, "年龄 必须是大于等于 18")
+		So(p.Instance, ShouldEqual, "/users")
+		So(len(p.Errors), ShouldEqual, 1)
+	})
+}
+
+func TestRender(t *testing.T) {
+	Convey("custom render", t, func() {
+		b := &Binder{Render: func(w http.ResponseWriter, r *http.Request, status int, errs []*FieldError) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"code": 1001, "msg": errs[0].Message})
+		}}
+		rec := serve(handler(b), `{"name":"wei","age":1}`)
+		So(rec.Code, ShouldEqual, http.StatusBadRequest)
+		So(rec.Body.String(), ShouldContainSubstring, `"code":1001`)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	h := Middleware(func() interface{} { return &UserForm{} })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		form := FromContext(r.Context()).(*UserForm)
+		_, _ = w.Write([]byte(form.Name))
+	}))
+
+	Convey("middleware", t, func() {
+		rec := serve(h, `{"name":"wei","age":20}`)
+		So(rec.Code, ShouldEqual, http.StatusOK)
+		So(rec.Body.String(), ShouldEqual, "wei")
+
+		rec = serve(h, `{"name":"wei"}`)
+		So(rec.Code, ShouldEqual, http.StatusOK)
+
+		rec = serve(h, `{"age":20}`)
+		So(rec.Code, ShouldEqual, http.StatusUnprocessableEntity)
+	})
+}
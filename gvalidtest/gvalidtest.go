@@ -0,0 +1,320 @@
+package gvalidtest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/booldesign/gvalid"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/24 10:00
+ * @Desc: 测试辅助, 断言验证结果, 表格驱动地对同一结构体做多种修改后验证, 失败时输出预期与实际错误的差异
+ */
+
+// TestingT testing.T 的子集
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// FieldError 预期的错误, Rule 为空时匹配该路径的任意规则
+type FieldError struct {
+	// Path 字段完整路径, 如 Address[0].City
+	Path string
+	// Rule 验证规则, 如 required, 自定义验证为 custom
+	Rule string
+}
+
+func (fe FieldError) String() string {
+	if fe.Rule == "" {
+		return fe.Path + " *"
+	}
+	return fe.Path + " " + fe.Rule
+}
+
+// Validate 验证 obj, 返回所有错误, 验证过程出错 (如 tag 格式错误) 时报告失败并返回 nil
+func Validate(t TestingT, obj interface{}, groups ...string) gvalid.Errors {
+	t.Helper()
+	valid := &gvalid.Validation{Groups: groups}
+	if _, err := valid.Valid(obj); err != nil {
+		t.Errorf("验证出错: %v", err)
+		return nil
+	}
+	return valid.Errors
+}
+
+// AssertValid 断言 obj 在验证场景 groups 下通过验证
+func AssertValid(t TestingT, obj interface{}, groups ...string) bool {
+	t.Helper()
+	valid := &gvalid.Validation{Groups: groups}
+	if _, err := valid.Valid(obj); err != nil {
+		t.Errorf("验证出错: %v", err)
+		return false
+	}
+	if valid.HasErrors() {
+		t.Errorf("预期通过验证, 实际错误:\n%s", errorLines(valid.Errors))
+		return false
+	}
+	return true
+}
+
+// AssertFieldError 断言 err 中包含 path 字段的 rule 错误, rule 为空时匹配任意规则
+// err 可以是 gvalid.Errors, []*gvalid.Error, *gvalid.Validation 或包装了 gvalid.Errors 的 error
+func AssertFieldError(t TestingT, err interface{}, path, rule string) bool {
+	t.Helper()
+	errs, ok := errorsOf(t, err)
+	if !ok {
+		return false
+	}
+	expected := FieldError{Path: path, Rule: rule}
+	for _, e := range errs {
+		if expected.match(e) {
+			return true
+		}
+	}
+	t.Errorf("缺少错误 %s, 实际错误:\n%s", expected, errorLines(errs))
+	return false
+}
+
+// AssertNoFieldError 断言 err 中不包含 path 字段的错误
+func AssertNoFieldError(t TestingT, err interface{}, path string) bool {
+	t.Helper()
+	errs, ok := errorsOf(t, err)
+	if !ok {
+		return false
+	}
+	var found []*gvalid.Error
+	for _, e := range errs {
+		if e.Path == path {
+			found = append(found, e)
+		}
+	}
+	if len(found) > 0 {
+		t.Errorf("预期 %s 没有错误, 实际错误:\n%s", path, errorLines(found))
+		return false
+	}
+	return true
+}
+
+// AssertErrors 断言 err 中的错误与 expected 完全一致, 不区分顺序, expected 为空时断言没有错误
+// 不一致时输出差异, - 为缺少的错误, + 为多余的错误
+func AssertErrors(t TestingT, err interface{}, expected ...FieldError) bool {
+	t.Helper()
+	errs, ok := errorsOf(t, err)
+	if !ok {
+		return false
+	}
+	if d, ok := diff(expected, errs); !ok {
+		t.Errorf("验证错误不一致 (- 缺少, + 多余):\n%s", d)
+		return false
+	}
+	return true
+}
+
+// Mutation 表格驱动测试的一行, 修改一个有效的结构体后验证
+type Mutation struct {
+	Name string
+	// Mutate 修改 obj, obj 为 base 返回的新对象, 为 nil 时不修改
+	Mutate func(obj interface{})
+	// Errors 预期的错误, 为空时预期通过验证
+	Errors []FieldError
+}
+
+// Run 对每个 Mutation 执行一个子测试: 调用 base 得到新对象, 执行 Mutate 后在验证场景 groups 下验证, 断言错误与 Errors 一致
+func Run(t *testing.T, base func() interface{}, mutations []Mutation, groups ...string) {
+	t.Helper()
+	for _, m := range mutations {
+		m := m
+		t.Run(m.Name, func(t *testing.T) {
+			t.Helper()
+			obj := base()
+			if m.Mutate != nil {
+				m.Mutate(obj)
+			}
+			valid := &gvalid.Validation{Groups: groups}
+			if _, err := valid.Valid(obj); err != nil {
+				t.Fatalf("验证出错: %v", err)
+			}
+			AssertErrors(t, valid, m.Errors...)
+		})
+	}
+}
+
+// Set 返回将 path 字段设置为 value 的 Mutate, path 同 Error.Path, 如 Address[0].City
+// 路径上的 nil 指针会自动分配, value 为 nil 时设置为零值, 类型不一致时尝试转换, 路径无效时 panic
+func Set(path string, value interface{}) func(obj interface{}) {
+	return func(obj interface{}) {
+		fv, err := lookup(reflect.ValueOf(obj), path)
+		if err != nil {
+			panic(fmt.Sprintf("gvalidtest: Set %s: %v", path, err))
+		}
+		if value == nil {
+			fv.Set(reflect.Zero(fv.Type()))
+			return
+		}
+		vOf := reflect.ValueOf(value)
+		switch {
+		case vOf.Type().AssignableTo(fv.Type()):
+		case vOf.Type().ConvertibleTo(fv.Type()):
+			vOf = vOf.Convert(fv.Type())
+		default:
+			panic(fmt.Sprintf("gvalidtest: Set %s: %s 不能赋值给 %s", path, vOf.Type(), fv.Type()))
+		}
+		fv.Set(vOf)
+	}
+}
+
+// lookup 按路径查找可设置的字段, 支持结构体字段及 slice, 数组下标
+func lookup(vOf reflect.Value, path string) (reflect.Value, error) {
+	if vOf.Kind() != reflect.Ptr || vOf.IsNil() {
+		return reflect.Value{}, errors.New("obj 必须是非 nil 指针")
+	}
+	for _, seg := range strings.Split(path, ".") {
+		name, indexes, err := splitSegment(seg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		vOf = alloc(vOf)
+		if vOf.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("%s 不是结构体", vOf.Type())
+		}
+		vOf = vOf.FieldByName(name)
+		if !vOf.IsValid() {
+			return reflect.Value{}, fmt.Errorf("字段 %s 不存在", name)
+		}
+		for _, i := range indexes {
+			vOf = alloc(vOf)
+			if vOf.Kind() != reflect.Slice && vOf.Kind() != reflect.Array {
+				return reflect.Value{}, fmt.Errorf("%s 不是 slice", name)
+			}
+			if i >= vOf.Len() {
+				return reflect.Value{}, fmt.Errorf("%s 下标 %d 越界, 长度 %d", name, i, vOf.Len())
+			}
+			vOf = vOf.Index(i)
+		}
+	}
+	if !vOf.CanSet() {
+		return reflect.Value{}, errors.New("字段不可设置")
+	}
+	return vOf, nil
+}
+
+// splitSegment 拆分 Items[0][1] 为字段名及下标
+func splitSegment(seg string) (name string, indexes []int, err error) {
+	i := strings.IndexByte(seg, '[')
+	if i < 0 {
+		return seg, nil, nil
+	}
+	name, rest := seg[:i], seg[i:]
+	for rest != "" {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return "", nil, fmt.Errorf("路径 %s 格式错误", seg)
+		}
+		n, err := strconv.Atoi(rest[1:end])
+		if err != nil || n < 0 {
+			return "", nil, fmt.Errorf("路径 %s 下标错误", seg)
+		}
+		indexes = append(indexes, n)
+		rest = rest[end+1:]
+	}
+	return name, indexes, nil
+}
+
+// alloc 解引用指针, nil 指针分配新值
+func alloc(vOf reflect.Value) reflect.Value {
+	for vOf.Kind() == reflect.Ptr {
+		if vOf.IsNil() && vOf.CanSet() {
+			vOf.Set(reflect.New(vOf.Type().Elem()))
+		}
+		vOf = vOf.Elem()
+	}
+	return vOf
+}
+
+// errorsOf 取出验证错误, 不支持的类型报告失败
+func errorsOf(t TestingT, err interface{}) ([]*gvalid.Error, bool) {
+	t.Helper()
+	switch e := err.(type) {
+	case nil:
+		return nil, true
+	case *gvalid.Validation:
+		return e.Errors, true
+	case []*gvalid.Error:
+		return e, true
+	case gvalid.Errors:
+		return e, true
+	case error:
+		var errs gvalid.Errors
+		if errors.As(e, &errs) {
+			return errs, true
+		}
+		t.Errorf("不是验证错误: %v", e)
+		return nil, false
+	}
+	t.Errorf("不支持的错误类型 %T", err)
+	return nil, false
+}
+
+func (fe FieldError) match(e *gvalid.Error) bool {
+	return fe.Path == e.Path && (fe.Rule == "" || fe.Rule == e.Rule)
+}
+
+// diff 预期与实际错误的差异, 按路径排序, 每个预期的错误匹配一个实际错误
+func diff(expected []FieldError, errs []*gvalid.Error) (string, bool) {
+	type line struct {
+		path, text string
+	}
+	var lines []line
+	used := make([]bool, len(errs))
+	same := true
+	for _, fe := range expected {
+		matched := false
+		for i, e := range errs {
+			if !used[i] && fe.match(e) {
+				used[i], matched = true, true
+				lines = append(lines, line{e.Path, "    " + errorLine(e)})
+				break
+			}
+		}
+		if !matched {
+			same = false
+			lines = append(lines, line{fe.Path, "  - " + fe.String()})
+		}
+	}
+	for i, e := range errs {
+		if !used[i] {
+			same = false
+			lines = append(lines, line{e.Path, "  + " + errorLine(e)})
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].path < lines[j].path })
+	s := make([]string, len(lines))
+	for i, l := range lines {
+		s[i] = l.text
+	}
+	return strings.Join(s, "\n"), same
+}
+
+func errorLine(e *gvalid.Error) string {
+	return fmt.Sprintf("%s %s: %s", e.Path, e.Rule, e.String())
+}
+
+func errorLines(errs []*gvalid.Error) string {
+	if len(errs) == 0 {
+		return "    (无)"
+	}
+	s := make([]string, len(errs))
+	for i, e := range errs {
+		s[i] = "    " + errorLine(e)
+	}
+	return strings.Join(s, "\n")
+}
@@ -0,0 +1,142 @@
+package gvalidtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/booldesign/gvalid"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/24 10:40
+ * @Desc:
+ */
+
+type Address struct {
+	City string `valid:"required" name:"市"`
+}
+
+type UserForm struct {
+	Name    string     `valid:"required,lte=10" name:"姓名"`
+	Age     int        `valid:"gte=18" name:"年龄"`
+	Address []*Address `valid:"dive" name:"地址"`
+	Extra   *Address   `name:"其它"`
+}
+
+func newUser() interface{} {
+	return &UserForm{Name: "wei", Age: 20, Address: []*Address{{City: "杭州"}}}
+}
+
+// fakeT 记录失败信息
+type fakeT struct {
+	errs []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+func TestAssert(t *testing.T) {
+	Convey("AssertValid", t, func() {
+		ft := &fakeT{}
+		So(AssertValid(ft, newUser()), ShouldBeTrue)
+		So(ft.errs, ShouldBeEmpty)
+
+		So(AssertValid(ft, &UserForm{Name: "wei", Age: 1}), ShouldBeFalse)
+		So(ft.errs, ShouldHaveLength, 1)
+		So(ft.errs[0], ShouldEqual, "预期通过验证, 实际错误:\n    Age gte: 年龄 必须是大于等于 18")
+
+		ft = &fakeT{}
+		So(AssertValid(ft, 1), ShouldBeFalse)
+		So(ft.errs[0], ShouldStartWith, "验证出错")
+	})
+
+	Convey("AssertFieldError", t, func() {
+		form := &UserForm{Age: 20, Address: []*Address{{}}}
+		errs := Validate(t, form)
+		ft := &fakeT{}
+		So(AssertFieldError(ft, errs, "Address[0].City", "required"), ShouldBeTrue)
+		So(AssertFieldError(ft, errs, "Name", ""), ShouldBeTrue)
+		So(AssertFieldError(ft, []*gvalid.Error(errs), "Name", "required"), ShouldBeTrue)
+		So(AssertFieldError(ft, fmt.Errorf("create: %w", errs), "Name", "required"), ShouldBeTrue)
+		So(ft.errs, ShouldBeEmpty)
+
+		So(AssertFieldError(ft, errs, "Age", "gte"), ShouldBeFalse)
+		So(ft.errs[0], ShouldEqual, "缺少错误 Age gte, 实际错误:\n"+
+			"    Name required: 姓名 不能为空或零值\n"+
+			"    Address[0].City required: 市 不能为空或零值")
+
+		ft = &fakeT{}
+		So(AssertFieldError(ft, fmt.Errorf("db"), "Name", "required"), ShouldBeFalse)
+		So(ft.errs[0], ShouldEqual, "不是验证错误: db")
+	})
+
+	Convey("AssertNoFieldError", t, func() {
+		valid := &gvalid.Validation{}
+		_, _ = valid.Valid(&UserForm{Age: 20})
+		ft := &fakeT{}
+		So(AssertNoFieldError(ft, valid, "Age"), ShouldBeTrue)
+		So(AssertNoFieldError(ft, valid, "Name"), ShouldBeFalse)
+		So(ft.errs[0], ShouldEqual, "预期 Name 没有错误, 实际错误:\n    Name required: 姓名 不能为空或零值")
+	})
+
+	Convey("AssertErrors diff", t, func() {
+		errs := Validate(t, &UserForm{Name: "abcdefghijkl", Age: 1, Address: []*Address{{}}})
+		ft := &fakeT{}
+		So(AssertErrors(ft, errs,
+			FieldError{Path: "Address[0].City", Rule: "required"},
+			FieldError{Path: "Age"},
+			FieldError{Path: "Name", Rule: "lte"},
+		), ShouldBeTrue)
+		So(AssertErrors(ft, nil), ShouldBeTrue)
+		So(ft.errs, ShouldBeEmpty)
+
+		So(AssertErrors(ft, errs,
+			FieldError{Path: "Address[0].City", Rule: "required"},
+			FieldError{Path: "Name", Rule: "required"},
+		), ShouldBeFalse)
+		So(ft.errs[0], ShouldEqual, strings.Join([]string{
+			"验证错误不一致 (- 缺少, + 多余):",
+			"    Address[0].City required: 市 不能为空或零值",
+			"  + Age gte: 年龄 必须是大于等于 18",
+			"  - Name required",
+			"  + Name lte: 姓名 长度必须是小于等于 10",
+		}, "\n"))
+	})
+}
+
+func TestSet(t *testing.T) {
+	Convey("Set", t, func() {
+		form := newUser().(*UserForm)
+		Set("Address[0].City", "")(form)
+		So(form.Address[0].City, ShouldEqual, "")
+		Set("Age", int8(3))(form)
+		So(form.Age, ShouldEqual, 3)
+		Set("Address", nil)(form)
+		So(form.Address, ShouldBeNil)
+		Set("Extra.City", "北京")(form)
+		So(form.Extra.City, ShouldEqual, "北京")
+
+		So(func() { Set("Address[0].City", "")(form) }, ShouldPanicWith, "gvalidtest: Set Address[0].City: Address 下标 0 越界, 长度 0")
+		So(func() { Set("Nick", "")(form) }, ShouldPanicWith, "gvalidtest: Set Nick: 字段 Nick 不存在")
+		So(func() { Set("Name", 1.5)(form) }, ShouldPanicWith, "gvalidtest: Set Name: float64 不能赋值给 string")
+	})
+}
+
+func TestRun(t *testing.T) {
+	Run(t, newUser, []Mutation{
+		{Name: "valid"},
+		{Name: "no name", Mutate: Set("Name", ""), Errors: []FieldError{{Path: "Name", Rule: "required"}}},
+		{Name: "long name", Mutate: Set("Name", "abcdefghijk"), Errors: []FieldError{{Path: "Name", Rule: "lte"}}},
+		{Name: "minor", Mutate: func(obj interface{}) {
+			obj.(*UserForm).Age = 17
+		}, Errors: []FieldError{{Path: "Age", Rule: "gte"}}},
+		{Name: "no city", Mutate: Set("Address[0].City", ""), Errors: []FieldError{{Path: "Address[0].City", Rule: "required"}}},
+	})
+}
@@ -0,0 +1,129 @@
+package conformance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/booldesign/gvalid"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 18:00
+ * @Desc: gvalid-gen 生成的代码与 Validation.Valid 的一致性测试用的类型
+ */
+
+//go:generate go run ../../cmd/gvalid-gen -type=Order
+
+// Status 值接收者的枚举
+type Status int
+
+const (
+	StatusNew Status = iota + 1
+	StatusPaid
+)
+
+func (s Status) IsValid() bool {
+	return s == StatusNew || s == StatusPaid
+}
+
+// Level 指针接收者的枚举
+type Level string
+
+func (l *Level) IsValid() bool {
+	return *l == "gold" || *l == "silver"
+}
+
+// Name 命名的字符串类型
+type Name string
+
+func init() {
+	gvalid.RegisterEnum("Status", StatusNew, StatusPaid)
+	gvalid.RegisterEnum("Color", "red", "blue")
+	gvalid.RegisterStructValidation(func(valid *gvalid.Validation, obj interface{}) {
+		if item := obj.(Item); item.Qty > 10 && item.Price > 100 {
+			valid.SetError("Qty", "数量", "大额订单数量不能超过 10")
+		}
+	}, Item{})
+	gvalid.RegisterStructValidation(func(valid *gvalid.Validation, obj interface{}) {
+		valid.SetError("Operator", "操作人", "不应执行")
+	}, audit{})
+}
+
+// ErrBoom ValidCtx 返回的错误
+var ErrBoom = errors.New("boom")
+
+type Base struct {
+	Id int64 `valid:"empty" groups:"create"`
+}
+
+// ValidCtx 提升到 Order, 只在 Order 中执行一次
+func (b *Base) ValidCtx(ctx context.Context, valid *gvalid.Validation, path string) error {
+	if b.Id < 0 {
+		valid.SetError("Id", "编号", "不能为负数 "+path)
+	}
+	if b.Id == -100 {
+		return ErrBoom
+	}
+	return nil
+}
+
+type audit struct {
+	Operator string `valid:"required" name:"操作人"`
+}
+
+type Address struct {
+	City  string   `valid:"required,trimSpace" name:"城市"`
+	Zip   string   `valid:"len=6,numeric"`
+	Lines []string `valid:"lte=2"`
+}
+
+func (a *Address) Valid(valid *gvalid.Validation) {
+	if a.City == "火星" {
+		valid.SetError("City", "城市", "不支持")
+	}
+}
+
+type Item struct {
+	Sku   string   `valid:"required,regex=(/^[A-Z]{3}-\\d+$/)"`
+	Qty   int8     `valid:"gte=1,lte=100" name:"数量"`
+	Price float64  `valid:"gt=0,lt=10000.5"`
+	Tags  []string `valid:"sin=new hot,distinct"`
+}
+
+type Order struct {
+	Base
+	audit
+
+	Name     Name              `valid:"trimSpace,required,gt=2,lte=20" name:"名称"`
+	Email    *string           `valid:"required,email"`
+	Mobile   string            `valid:"mobile"`
+	IdCard   string            `valid:"idCard,sensitive=idCard"`
+	Site     string            `valid:"url"`
+	Ip       string            `valid:"ip"`
+	Avatar   string            `valid:"base64"`
+	Date     string            `valid:"date=2006-01-02,strGte=2020-01-01,strLte=2029-12-31"`
+	Kind     string            `valid:"in='a b' c,default=c"`
+	Level    Level             `valid:"enum"`
+	Status   Status            `valid:"required,enum,in=1 2"`
+	Statuses []*Status         `valid:"enum"`
+	Codes    []int             `valid:"distinct,sin=1 2 3"`
+	Ids      []int64           `valid:"sin=1 2"`
+	Rate     float32           `valid:"gte=0.5"`
+	Count    *int              `valid:"gt=0"`
+	Note     sql.NullString    `valid:"required,trimSpace,lte=5"`
+	Score    sql.NullInt64     `valid:"default=60,lte=100"`
+	Paid     bool              `valid:"required" groups:"pay"`
+	Password string            `valid:"gte=6@create"`
+	Phone    string            `valid:"numeric,len=11,sensitive"`
+	Color    string            `valid:"enum=Color"`
+	Address  *Address          `valid:"dive"`
+	Items    []*Item           `valid:"required,dive"`
+	Backup   Address           `valid:"dive" groups:"update"`
+	Meta     map[string]string `valid:"lte=2"`
+	Version  int               `valid:"immutable"`
+	Secret   string            `valid:"-"`
+	remark   string            `valid:"required,lte=3"`
+}
@@ -0,0 +1,207 @@
+package conformance
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/booldesign/gvalid"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 18:30
+ * @Desc:
+ */
+
+func newOrder() *Order {
+	email, count := "a@b.com", 1
+	paid, bad := StatusPaid, Status(9)
+	return &Order{
+		Base:     Base{Id: 0},
+		audit:    audit{Operator: "admin"},
+		Name:     "  订单名称  ",
+		Email:    &email,
+		Mobile:   "13800138000",
+		IdCard:   "11010519491231002X",
+		Site:     "https://example.com",
+		Ip:       "127.0.0.1",
+		Avatar:   "aGVsbG8=",
+		Date:     "2026-10-21",
+		Level:    "gold",
+		Status:   StatusNew,
+		Statuses: []*Status{&paid, nil, &bad}[:2],
+		Codes:    []int{1, 2},
+		Ids:      []int64{1},
+		Rate:     0.5,
+		Count:    &count,
+		Note:     sql.NullString{String: " ok ", Valid: true},
+		Score:    sql.NullInt64{Valid: true},
+		Paid:     true,
+		Password: "123456",
+		Phone:    "13800138000",
+		Color:    "red",
+		Address:  &Address{City: "上海", Zip: "200000"},
+		Items:    []*Item{{Sku: "ABC-1", Qty: 1, Price: 1, Tags: []string{"new"}}, nil},
+		Backup:   Address{City: "北京"},
+		remark:   "ok",
+	}
+}
+
+var mutations = map[string]func(o *Order){
+	"valid": func(o *Order) {},
+	"zero": func(o *Order) {
+		*o = Order{}
+	},
+	"strings": func(o *Order) {
+		o.Name = " 名 "
+		o.Mobile, o.IdCard, o.Site, o.Ip, o.Avatar = "1380013800", "110105194912310021", "example", "256.1.1.1", "a=b"
+		o.Date, o.Kind, o.Phone, o.Color, o.remark = "2026/10/21", "d", "1380013800a", "green", "remark"
+	},
+	"date range": func(o *Order) {
+		o.Date = "2019-12-31"
+	},
+	"date range max": func(o *Order) {
+		o.Date = "2030-01-01"
+	},
+	"long name": func(o *Order) {
+		o.Name = Name(strings.Repeat("名", 21))
+	},
+	"numbers": func(o *Order) {
+		count := 0
+		o.Rate, o.Count, o.Status = 0.4, &count, 3
+		o.Score = sql.NullInt64{Int64: 101, Valid: true}
+	},
+	"nan": func(o *Order) {
+		o.Items[0].Price = math.NaN()
+	},
+	"enum": func(o *Order) {
+		bad := Status(9)
+		o.Level, o.Statuses = "bronze", []*Status{nil, &bad}
+	},
+	"slices": func(o *Order) {
+		o.Codes, o.Ids = []int{1, 4, 1}, []int64{3}
+		o.Meta = map[string]string{"a": "1", "b": "2", "c": "3"}
+	},
+	"empty slices": func(o *Order) {
+		o.Codes, o.Items, o.Meta = []int{}, []*Item{}, map[string]string{}
+	},
+	"null": func(o *Order) {
+		o.Note = sql.NullString{String: "  ", Valid: true}
+		o.Score = sql.NullInt64{}
+	},
+	"null invalid": func(o *Order) {
+		o.Note = sql.NullString{String: "too long", Valid: false}
+	},
+	"null long": func(o *Order) {
+		o.Note = sql.NullString{String: "too long", Valid: true}
+	},
+	"nil pointers": func(o *Order) {
+		o.Email, o.Count, o.Address = nil, nil, nil
+	},
+	"pointer to zero": func(o *Order) {
+		email := ""
+		o.Email = &email
+	},
+	"nested": func(o *Order) {
+		o.Address = &Address{City: " 火星 ", Zip: "20000a", Lines: []string{"1", "2", "3"}}
+		o.Items = []*Item{nil, {Sku: "abc", Qty: 101, Price: 10000.5, Tags: []string{"new", "old", "new"}}, {Sku: "ABC-2", Qty: 20, Price: 200}}
+		o.Backup = Address{City: "火星"}
+	},
+	"embedded": func(o *Order) {
+		o.Id, o.Operator = -1, ""
+	},
+	"custom error": func(o *Order) {
+		o.Id = -100
+	},
+	"sensitive": func(o *Order) {
+		o.Mobile, o.Phone, o.IdCard = "138001380001", "1380013800", "1101051949123100"
+	},
+	"groups": func(o *Order) {
+		o.Id, o.Paid, o.Password = 1, false, "123"
+	},
+}
+
+// withoutNaN NaN 不能使用 ShouldResemble 比较, 替换为 0
+func withoutNaN(o *Order, errs []*gvalid.Error) {
+	for _, item := range o.Items {
+		if item != nil && math.IsNaN(item.Price) {
+			item.Price = 0
+		}
+	}
+	for _, e := range errs {
+		if f, ok := e.Value.(float64); ok && math.IsNaN(f) {
+			e.Value = 0.0
+		}
+	}
+}
+
+var groupsList = [][]string{nil, {"create"}, {"pay", "update"}}
+
+func TestConformance(t *testing.T) {
+	Convey("test generated code conforms to Validation.Valid", t, func() {
+		for name, mutate := range mutations {
+			for _, groups := range groupsList {
+				Convey(fmt.Sprintf("%s %v", name, groups), func() {
+					expected, actual := newOrder(), newOrder()
+					mutate(expected)
+					mutate(actual)
+
+					valid := &gvalid.Validation{Groups: groups}
+					_, validErr := valid.Valid(expected)
+					err := actual.ValidateGroups(groups...)
+
+					if validErr != nil {
+						So(err, ShouldEqual, validErr)
+						return
+					}
+					var errs gvalid.Errors
+					if len(valid.Errors) == 0 {
+						So(err, ShouldBeNil)
+					} else {
+						So(errors.As(err, &errs), ShouldBeTrue)
+					}
+					withoutNaN(expected, valid.Errors)
+					withoutNaN(actual, errs)
+					So([]*gvalid.Error(errs), ShouldResemble, valid.Errors)
+					// default, trimSpace, dive 的空指针修改结构体
+					So(actual, ShouldResemble, expected)
+				})
+			}
+		}
+	})
+
+	Convey("test generated code", t, func() {
+		o := newOrder()
+		So(o.Validate(), ShouldBeNil)
+		So(o.Name, ShouldEqual, "订单名称")
+		So(o.Kind, ShouldEqual, "c")
+
+		o.Email = nil
+		err := o.Validate()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, " 不能为空或零值")
+		var errs gvalid.Errors
+		So(errors.As(err, &errs), ShouldBeTrue)
+		So(errs[0].Path, ShouldEqual, "Email")
+		So(errs[0].Code, ShouldEqual, "ERR_REQUIRED")
+	})
+}
+
+func BenchmarkValid(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		valid := &gvalid.Validation{}
+		_, _ = valid.Valid(newOrder())
+	}
+}
+
+func BenchmarkGenerated(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = newOrder().Validate()
+	}
+}
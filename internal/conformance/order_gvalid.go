@@ -0,0 +1,377 @@
+// Code generated by gvalid-gen. DO NOT EDIT.
+
+package conformance
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/booldesign/gvalid"
+)
+
+var gvalidRegexp0 = regexp.MustCompile("^[A-Z]{3}-\\d+$")
+
+// Validate 验证, 与 gvalid.Validation.Valid 的结果一致, 错误为 gvalid.Errors
+func (x *Order) Validate() error {
+	return x.ValidateGroups()
+}
+
+// ValidateGroups 验证场景 groups 下验证, 同 gvalid.Validation.Groups
+func (x *Order) ValidateGroups(groups ...string) error {
+	g := &gvalid.Generated{Groups: groups}
+	if err := x.gvalidValidate(g, nil); err != nil {
+		return err
+	}
+	return g.Err()
+}
+
+func (x *Order) gvalidValidate(g *gvalid.Generated, embeddedIn interface{}) error {
+	{
+		prev := g.Enter("", true)
+		if err := x.Base.gvalidValidate(g, x); err != nil {
+			return err
+		}
+		g.Leave(prev)
+	}
+	{
+		prev := g.Enter("", false)
+		if err := x.audit.gvalidValidate(g, x); err != nil {
+			return err
+		}
+		g.Leave(prev)
+	}
+	if x.Name != "" {
+		x.Name = Name(strings.TrimSpace(string(x.Name)))
+	}
+	if x.Name == "" {
+		g.Fail(&gvalid.Error{Field: "Name", Name: "名称", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "string", Value: x.Name}, "")
+	}
+	if x.Name != "" && !(utf8.RuneCountInString(string(x.Name)) > 2) {
+		g.Fail(&gvalid.Error{Field: "Name", Name: "名称", Message: fmt.Sprintf(gvalid.ValidateValNotGtString, 2), Rule: "gt", Param: "2", Params: []string{"2"}, Kind: "string", Value: x.Name}, "")
+	}
+	if x.Name != "" && !(utf8.RuneCountInString(string(x.Name)) <= 20) {
+		g.Fail(&gvalid.Error{Field: "Name", Name: "名称", Message: fmt.Sprintf(gvalid.ValidateValNotLteString, 20), Rule: "lte", Param: "20", Params: []string{"20"}, Kind: "string", Value: x.Name}, "")
+	}
+	if x.Email == nil {
+		g.Fail(&gvalid.Error{Field: "Email", Name: "", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "", Value: nil}, "")
+	}
+	if x.Email != nil && !gvalid.ValidEmail(*x.Email) {
+		g.Fail(&gvalid.Error{Field: "Email", Name: "", Message: gvalid.ValidateValNotFormatErr, Rule: "email", Param: "", Kind: "string", Value: *x.Email}, "")
+	}
+	if x.Mobile != "" && !gvalid.ValidMobile(x.Mobile) {
+		g.Fail(&gvalid.Error{Field: "Mobile", Name: "", Message: gvalid.ValidateValNotFormatErr, Rule: "mobile", Param: "", Kind: "string", Value: x.Mobile}, "")
+	}
+	if x.IdCard != "" && !gvalid.ValidIdCardCode(x.IdCard) {
+		g.Fail(&gvalid.Error{Field: "IdCard", Name: "", Message: gvalid.ValidateValNotFormatErr, Rule: "idCard", Param: "", Kind: "string", Value: x.IdCard}, "idCard")
+	}
+	if x.Site != "" && !gvalid.ValidUrl(x.Site) {
+		g.Fail(&gvalid.Error{Field: "Site", Name: "", Message: gvalid.ValidateValNotFormatErr, Rule: "url", Param: "", Kind: "string", Value: x.Site}, "")
+	}
+	if x.Ip != "" && !gvalid.ValidIp(x.Ip) {
+		g.Fail(&gvalid.Error{Field: "Ip", Name: "", Message: gvalid.ValidateValNotFormatErr, Rule: "ip", Param: "", Kind: "string", Value: x.Ip}, "")
+	}
+	if x.Avatar != "" && !gvalid.ValidBase64(x.Avatar) {
+		g.Fail(&gvalid.Error{Field: "Avatar", Name: "", Message: gvalid.ValidateValNotFormatErr, Rule: "base64", Param: "", Kind: "string", Value: x.Avatar}, "")
+	}
+	if x.Date != "" {
+		if _, err := time.ParseInLocation("2006-01-02", x.Date, gvalid.Location()); err != nil {
+			g.Fail(&gvalid.Error{Field: "Date", Name: "", Message: fmt.Sprintf(gvalid.ValidateValDateFormatErr, "2006-01-02"), Rule: "date", Param: "2006-01-02", Params: []string{"2006-01-02"}, Kind: "string", Value: x.Date}, "")
+		}
+	}
+	if x.Date != "" && !(x.Date >= "2020-01-01") {
+		g.Fail(&gvalid.Error{Field: "Date", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotStrGte, "2020-01-01"), Rule: "strGte", Param: "2020-01-01", Params: []string{"2020-01-01"}, Kind: "string", Value: x.Date}, "")
+	}
+	if x.Date != "" && !(x.Date <= "2029-12-31") {
+		g.Fail(&gvalid.Error{Field: "Date", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotStrLte, "2029-12-31"), Rule: "strLte", Param: "2029-12-31", Params: []string{"2029-12-31"}, Kind: "string", Value: x.Date}, "")
+	}
+	if x.Kind != "" && x.Kind != "a b" && x.Kind != "c" {
+		g.Fail(&gvalid.Error{Field: "Kind", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotExists, "'a b' c"), Rule: "in", Param: "a b c", Params: []string{"a b", "c"}, Kind: "string", Value: x.Kind}, "")
+	}
+	if x.Kind == "" {
+		x.Kind = "c"
+	}
+	if x.Level != "" && !x.Level.IsValid() {
+		g.Fail(&gvalid.Error{Field: "Level", Name: "", Message: gvalid.EnumMessage("Level"), Rule: "enum", Param: "", Kind: "string", Value: x.Level}, "")
+	}
+	if x.Status == 0 {
+		g.Fail(&gvalid.Error{Field: "Status", Name: "", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "int", Value: x.Status}, "")
+	}
+	if x.Status != 0 && !x.Status.IsValid() {
+		g.Fail(&gvalid.Error{Field: "Status", Name: "", Message: gvalid.EnumMessage("Status"), Rule: "enum", Param: "", Kind: "int", Value: x.Status}, "")
+	}
+	if x.Status != 0 && int64(x.Status) != 1 && int64(x.Status) != 2 {
+		g.Fail(&gvalid.Error{Field: "Status", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotExists, "1 2"), Rule: "in", Param: "1 2", Params: []string{"1", "2"}, Kind: "int", Value: x.Status}, "")
+	}
+	if x.Statuses != nil {
+		for i := range x.Statuses {
+			if x.Statuses[i] != nil && !x.Statuses[i].IsValid() {
+				g.Fail(&gvalid.Error{Field: "Statuses", Name: "", Message: gvalid.EnumMessage("Status"), Rule: "enum", Param: "", Kind: "slice", Value: x.Statuses}, "")
+				break
+			}
+		}
+	}
+	if x.Codes != nil {
+		seen := make(map[int]struct{}, len(x.Codes))
+		for _, e := range x.Codes {
+			if _, ok := seen[e]; ok {
+				g.Fail(&gvalid.Error{Field: "Codes", Name: "", Message: fmt.Sprintf(gvalid.ValidateValMustDistinct, g.Display("Codes", "", x.Codes)), Rule: "distinct", Param: "", Kind: "slice", Value: x.Codes}, "")
+				break
+			}
+			seen[e] = struct{}{}
+		}
+	}
+	if x.Codes != nil {
+		for _, e := range x.Codes {
+			if e != 1 && e != 2 && e != 3 {
+				g.Fail(&gvalid.Error{Field: "Codes", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotExistsSlice, "1 2 3"), Rule: "sin", Param: "1 2 3", Params: []string{"1", "2", "3"}, Kind: "slice", Value: x.Codes}, "")
+				break
+			}
+		}
+	}
+	if x.Ids != nil {
+		for _, e := range x.Ids {
+			if e != 1 && e != 2 {
+				g.Fail(&gvalid.Error{Field: "Ids", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotExistsSlice, "1 2"), Rule: "sin", Param: "1 2", Params: []string{"1", "2"}, Kind: "slice", Value: x.Ids}, "")
+				break
+			}
+		}
+	}
+	if x.Rate != 0 && !(float64(x.Rate) >= 0.5) {
+		g.Fail(&gvalid.Error{Field: "Rate", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotGteFloat, float64(0.5)), Rule: "gte", Param: "0.5", Params: []string{"0.5"}, Kind: "float32", Value: x.Rate}, "")
+	}
+	if x.Count != nil && !(*x.Count > 0) {
+		g.Fail(&gvalid.Error{Field: "Count", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotGtInt, 0), Rule: "gt", Param: "0", Params: []string{"0"}, Kind: "int", Value: *x.Count}, "")
+	}
+	{
+		cv := x.Note.String
+		if !x.Note.Valid {
+			g.Fail(&gvalid.Error{Field: "Note", Name: "", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "struct", Value: sql.NullString{}}, "")
+		} else if cv == "" {
+			g.Fail(&gvalid.Error{Field: "Note", Name: "", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "string", Value: cv}, "")
+		}
+		if x.Note.Valid && cv != "" {
+			cv = strings.TrimSpace(cv)
+		}
+		if x.Note.Valid && cv != "" && !(utf8.RuneCountInString(cv) <= 5) {
+			g.Fail(&gvalid.Error{Field: "Note", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotLteString, 5), Rule: "lte", Param: "5", Params: []string{"5"}, Kind: "string", Value: cv}, "")
+		}
+	}
+	{
+		cv := x.Score.Int64
+		if x.Score.Valid && cv == 0 {
+			cv = 60
+		}
+		if x.Score.Valid && cv != 0 && !(int(cv) <= 100) {
+			g.Fail(&gvalid.Error{Field: "Score", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotLteInt, 100), Rule: "lte", Param: "100", Params: []string{"100"}, Kind: "int64", Value: cv}, "")
+		}
+	}
+	if g.InGroups("pay") {
+		if !x.Paid {
+			g.Fail(&gvalid.Error{Field: "Paid", Name: "", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "bool", Value: x.Paid}, "")
+		}
+	}
+	if g.InGroups("create") {
+		if x.Password != "" && !(utf8.RuneCountInString(x.Password) >= 6) {
+			g.Fail(&gvalid.Error{Field: "Password", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotGteString, 6), Rule: "gte", Param: "6", Params: []string{"6"}, Kind: "string", Value: x.Password}, "")
+		}
+	}
+	if x.Phone != "" {
+		for _, c := range x.Phone {
+			if c < '0' || c > '9' {
+				g.Fail(&gvalid.Error{Field: "Phone", Name: "", Message: gvalid.ValidateValNotNumericErr, Rule: "numeric", Param: "", Kind: "string", Value: x.Phone}, "full")
+				break
+			}
+		}
+	}
+	if x.Phone != "" && !(utf8.RuneCountInString(x.Phone) == 11) {
+		g.Fail(&gvalid.Error{Field: "Phone", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotLenString, 11), Rule: "len", Param: "11", Params: []string{"11"}, Kind: "string", Value: x.Phone}, "full")
+	}
+	if x.Color != "" {
+		if msg := gvalid.CheckEnum("Color", x.Color); msg != "" {
+			g.Fail(&gvalid.Error{Field: "Color", Name: "", Message: msg, Rule: "enum", Param: "Color", Params: []string{"Color"}, Kind: "string", Value: x.Color}, "")
+		}
+	}
+	if x.Address == nil {
+		x.Address = new(Address)
+	}
+	{
+		prev := g.Enter("Address", true)
+		if err := x.Address.gvalidValidate(g, nil); err != nil {
+			return err
+		}
+		g.Leave(prev)
+	}
+	if x.Items == nil {
+		g.Fail(&gvalid.Error{Field: "Items", Name: "", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "slice", Value: x.Items}, "")
+	}
+	for i := range x.Items {
+		if x.Items[i] == nil {
+			continue
+		}
+		prev := g.Enter("Items"+"["+strconv.Itoa(i)+"]", true)
+		if err := x.Items[i].gvalidValidate(g, nil); err != nil {
+			return err
+		}
+		g.Leave(prev)
+	}
+	if g.InGroups("update") {
+		{
+			prev := g.Enter("Backup", true)
+			if err := x.Backup.gvalidValidate(g, nil); err != nil {
+				return err
+			}
+			g.Leave(prev)
+		}
+	}
+	if x.Meta != nil && !(len(x.Meta) <= 2) {
+		g.Fail(&gvalid.Error{Field: "Meta", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotLteSlice, 2), Rule: "lte", Param: "2", Params: []string{"2"}, Kind: "map", Value: x.Meta}, "")
+	}
+	if x.remark == "" {
+		g.Fail(&gvalid.Error{Field: "remark", Name: "", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "string", Value: nil}, "")
+	}
+	if x.remark != "" && !(utf8.RuneCountInString(x.remark) <= 3) {
+		g.Fail(&gvalid.Error{Field: "remark", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotLteString, 3), Rule: "lte", Param: "3", Params: []string{"3"}, Kind: "string", Value: nil}, "")
+	}
+	return g.Struct(x, embeddedIn)
+}
+
+// Validate 验证, 与 gvalid.Validation.Valid 的结果一致, 错误为 gvalid.Errors
+func (x *Base) Validate() error {
+	return x.ValidateGroups()
+}
+
+// ValidateGroups 验证场景 groups 下验证, 同 gvalid.Validation.Groups
+func (x *Base) ValidateGroups(groups ...string) error {
+	g := &gvalid.Generated{Groups: groups}
+	if err := x.gvalidValidate(g, nil); err != nil {
+		return err
+	}
+	return g.Err()
+}
+
+func (x *Base) gvalidValidate(g *gvalid.Generated, embeddedIn interface{}) error {
+	if g.InGroups("create") {
+		if x.Id != 0 {
+			g.Fail(&gvalid.Error{Field: "Id", Name: "", Message: gvalid.ValidateValMustEmpty, Rule: "empty", Param: "", Kind: "int64", Value: x.Id}, "")
+		}
+	}
+	return g.Struct(x, embeddedIn)
+}
+
+// Validate 验证, 与 gvalid.Validation.Valid 的结果一致, 错误为 gvalid.Errors
+func (x *audit) Validate() error {
+	return x.ValidateGroups()
+}
+
+// ValidateGroups 验证场景 groups 下验证, 同 gvalid.Validation.Groups
+func (x *audit) ValidateGroups(groups ...string) error {
+	g := &gvalid.Generated{Groups: groups}
+	if err := x.gvalidValidate(g, nil); err != nil {
+		return err
+	}
+	return g.Err()
+}
+
+func (x *audit) gvalidValidate(g *gvalid.Generated, embeddedIn interface{}) error {
+	if x.Operator == "" {
+		g.Fail(&gvalid.Error{Field: "Operator", Name: "操作人", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "string", Value: x.Operator}, "")
+	}
+	return g.Struct(x, embeddedIn)
+}
+
+// Validate 验证, 与 gvalid.Validation.Valid 的结果一致, 错误为 gvalid.Errors
+func (x *Address) Validate() error {
+	return x.ValidateGroups()
+}
+
+// ValidateGroups 验证场景 groups 下验证, 同 gvalid.Validation.Groups
+func (x *Address) ValidateGroups(groups ...string) error {
+	g := &gvalid.Generated{Groups: groups}
+	if err := x.gvalidValidate(g, nil); err != nil {
+		return err
+	}
+	return g.Err()
+}
+
+func (x *Address) gvalidValidate(g *gvalid.Generated, embeddedIn interface{}) error {
+	if x.City == "" {
+		g.Fail(&gvalid.Error{Field: "City", Name: "城市", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "string", Value: x.City}, "")
+	}
+	if x.City != "" {
+		x.City = strings.TrimSpace(x.City)
+	}
+	if x.Zip != "" && !(utf8.RuneCountInString(x.Zip) == 6) {
+		g.Fail(&gvalid.Error{Field: "Zip", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotLenString, 6), Rule: "len", Param: "6", Params: []string{"6"}, Kind: "string", Value: x.Zip}, "")
+	}
+	if x.Zip != "" {
+		for _, c := range x.Zip {
+			if c < '0' || c > '9' {
+				g.Fail(&gvalid.Error{Field: "Zip", Name: "", Message: gvalid.ValidateValNotNumericErr, Rule: "numeric", Param: "", Kind: "string", Value: x.Zip}, "")
+				break
+			}
+		}
+	}
+	if x.Lines != nil && !(len(x.Lines) <= 2) {
+		g.Fail(&gvalid.Error{Field: "Lines", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotLteSlice, 2), Rule: "lte", Param: "2", Params: []string{"2"}, Kind: "slice", Value: x.Lines}, "")
+	}
+	return g.Struct(x, embeddedIn)
+}
+
+// Validate 验证, 与 gvalid.Validation.Valid 的结果一致, 错误为 gvalid.Errors
+func (x *Item) Validate() error {
+	return x.ValidateGroups()
+}
+
+// ValidateGroups 验证场景 groups 下验证, 同 gvalid.Validation.Groups
+func (x *Item) ValidateGroups(groups ...string) error {
+	g := &gvalid.Generated{Groups: groups}
+	if err := x.gvalidValidate(g, nil); err != nil {
+		return err
+	}
+	return g.Err()
+}
+
+func (x *Item) gvalidValidate(g *gvalid.Generated, embeddedIn interface{}) error {
+	if x.Sku == "" {
+		g.Fail(&gvalid.Error{Field: "Sku", Name: "", Message: gvalid.ValidateValCanNotEmpty, Rule: "required", Param: "", Kind: "string", Value: x.Sku}, "")
+	}
+	if x.Sku != "" && !gvalidRegexp0.MatchString(x.Sku) {
+		g.Fail(&gvalid.Error{Field: "Sku", Name: "", Message: gvalid.ValidateValNotFormatErr, Rule: "regex", Param: "^[A-Z]{3}-\\d+$", Params: []string{"^[A-Z]{3}-\\d+$"}, Kind: "string", Value: x.Sku}, "")
+	}
+	if x.Qty != 0 && !(int(x.Qty) >= 1) {
+		g.Fail(&gvalid.Error{Field: "Qty", Name: "数量", Message: fmt.Sprintf(gvalid.ValidateValNotGteInt, 1), Rule: "gte", Param: "1", Params: []string{"1"}, Kind: "int8", Value: x.Qty}, "")
+	}
+	if x.Qty != 0 && !(int(x.Qty) <= 100) {
+		g.Fail(&gvalid.Error{Field: "Qty", Name: "数量", Message: fmt.Sprintf(gvalid.ValidateValNotLteInt, 100), Rule: "lte", Param: "100", Params: []string{"100"}, Kind: "int8", Value: x.Qty}, "")
+	}
+	if x.Price != 0 && !(x.Price > 0) {
+		g.Fail(&gvalid.Error{Field: "Price", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotGtFloat, float64(0)), Rule: "gt", Param: "0", Params: []string{"0"}, Kind: "float64", Value: x.Price}, "")
+	}
+	if x.Price != 0 && !(x.Price < 10000.5) {
+		g.Fail(&gvalid.Error{Field: "Price", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotLtFloat, float64(10000.5)), Rule: "lt", Param: "10000.5", Params: []string{"10000.5"}, Kind: "float64", Value: x.Price}, "")
+	}
+	if x.Tags != nil {
+		for _, e := range x.Tags {
+			if e != "new" && e != "hot" {
+				g.Fail(&gvalid.Error{Field: "Tags", Name: "", Message: fmt.Sprintf(gvalid.ValidateValNotExistsSlice, "new hot"), Rule: "sin", Param: "new hot", Params: []string{"new", "hot"}, Kind: "slice", Value: x.Tags}, "")
+				break
+			}
+		}
+	}
+	if x.Tags != nil {
+		seen := make(map[string]struct{}, len(x.Tags))
+		for _, e := range x.Tags {
+			if _, ok := seen[e]; ok {
+				g.Fail(&gvalid.Error{Field: "Tags", Name: "", Message: fmt.Sprintf(gvalid.ValidateValMustDistinct, g.Display("Tags", "", x.Tags)), Rule: "distinct", Param: "", Kind: "slice", Value: x.Tags}, "")
+				break
+			}
+			seen[e] = struct{}{}
+		}
+	}
+	return g.Struct(x, embeddedIn)
+}
@@ -0,0 +1,113 @@
+package gotypes
+
+import (
+	"go/types"
+	"reflect"
+
+	"github.com/booldesign/gvalid"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/21 16:30
+ * @Desc: go/types 类型转换为 gvalid.FieldType, 供 analysis 和 gvalid-gen 使用
+ */
+
+// FieldType 对应 gvalid.NewFieldType 的静态类型
+func FieldType(t types.Type) *gvalid.FieldType {
+	return fieldType(t, true)
+}
+
+func fieldType(t types.Type, elem bool) *gvalid.FieldType {
+	t = Deref(t)
+	ft := &gvalid.FieldType{
+		Type:   types.TypeString(t, func(p *types.Package) string { return p.Name() }),
+		Enum:   HasMethod(t, "IsValid", 0, "bool"),
+		Custom: HasMethod(t, "Value", 0, "database/sql/driver.Value", "error"),
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		ft.Kind = basicKinds[u.Kind()]
+	case *types.Slice:
+		ft.Kind = reflect.Slice
+		if elem {
+			ft.Elem = fieldType(u.Elem(), false)
+		}
+	case *types.Array:
+		ft.Kind = reflect.Array
+		if elem {
+			ft.Elem = fieldType(u.Elem(), false)
+		}
+	case *types.Map:
+		ft.Kind = reflect.Map
+		if elem {
+			ft.Elem = fieldType(u.Elem(), false)
+		}
+	case *types.Struct:
+		ft.Kind = reflect.Struct
+	case *types.Interface:
+		ft.Kind = reflect.Interface
+	case *types.Chan:
+		ft.Kind = reflect.Chan
+	case *types.Signature:
+		ft.Kind = reflect.Func
+	}
+	return ft
+}
+
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool:          reflect.Bool,
+	types.Int:           reflect.Int,
+	types.Int8:          reflect.Int8,
+	types.Int16:         reflect.Int16,
+	types.Int32:         reflect.Int32,
+	types.Int64:         reflect.Int64,
+	types.Uint:          reflect.Uint,
+	types.Uint8:         reflect.Uint8,
+	types.Uint16:        reflect.Uint16,
+	types.Uint32:        reflect.Uint32,
+	types.Uint64:        reflect.Uint64,
+	types.Uintptr:       reflect.Uintptr,
+	types.Float32:       reflect.Float32,
+	types.Float64:       reflect.Float64,
+	types.Complex64:     reflect.Complex64,
+	types.Complex128:    reflect.Complex128,
+	types.String:        reflect.String,
+	types.UnsafePointer: reflect.UnsafePointer,
+}
+
+// HasMethod 类型或其指针是否有导出的方法 name, 参数个数为 params, 返回值类型为 results
+func HasMethod(t types.Type, name string, params int, results ...string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), false, nil, name)
+	fn, ok := obj.(*types.Func)
+	if !ok || !fn.Exported() {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != params || sig.Results().Len() != len(results) {
+		return false
+	}
+	for i, r := range results {
+		if types.TypeString(sig.Results().At(i).Type(), nil) != r {
+			return false
+		}
+	}
+	return true
+}
+
+// TypeName 去除指针后的类型全名, 如 example.com/money.Money
+func TypeName(t types.Type) string {
+	return types.TypeString(Deref(t), nil)
+}
+
+// Deref 去除指针
+func Deref(t types.Type) types.Type {
+	for {
+		p, ok := t.(*types.Pointer)
+		if !ok {
+			return t
+		}
+		t = p.Elem()
+	}
+}
@@ -0,0 +1,104 @@
+package gotypes
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/booldesign/gvalid"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/23 16:00
+ * @Desc: 加载包并读取字段规则, 供 gvalid-gen 和 gvalid-doc 使用
+ */
+
+// GeneratedHeader gvalid-gen 生成的文件头, 加载包时跳过带有此文件头的文件
+const GeneratedHeader = "// Code generated by gvalid-gen. DO NOT EDIT."
+
+// Package 解析并检查后的包
+type Package struct {
+	Fset  *token.FileSet
+	Files []*ast.File
+	Types *types.Package
+}
+
+// LoadDir 解析并检查 dir 中的包, 忽略生成的文件和类型错误
+func LoadDir(dir string) (*Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if len(f.Comments) > 0 && f.Comments[0].Pos() < f.Package && strings.Contains(f.Comments[0].Text(), strings.TrimPrefix(GeneratedHeader, "// ")) {
+			continue
+		}
+		files = append(files, f)
+	}
+	// 生成的方法被跳过, 引用它们的代码会有类型错误, 不影响结构体的类型
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil), Error: func(error) {}}
+	pkg, _ := conf.Check(bp.ImportPath, fset, files, nil)
+	return &Package{Fset: fset, Files: files, Types: pkg}, nil
+}
+
+// FieldRules 同 gvalid 中的字段规则, 没有 tag 的匿名结构体为 dive
+func FieldRules(v *types.Var, tag reflect.StructTag) (gvalid.RuleSet, error) {
+	vt := tag.Get("valid")
+	if v.Anonymous() && IsStruct(Deref(v.Type())) && vt == "" {
+		return gvalid.RuleSet{{Name: "dive"}}, nil
+	}
+	if vt == "" || vt == "-" {
+		return nil, nil
+	}
+	rules, err := gvalid.ParseTag(vt)
+	if err != nil {
+		return nil, err
+	}
+	if groups := tag.Get("groups"); groups != "" {
+		for i := range rules {
+			if len(rules[i].Groups) == 0 {
+				for _, s := range strings.Split(groups, ",") {
+					if s = strings.TrimSpace(s); s != "" {
+						rules[i].Groups = append(rules[i].Groups, s)
+					}
+				}
+			}
+		}
+	}
+	return rules, nil
+}
+
+// JSONName 同 encoding/json 的字段名, json:"-" 及没有 json 名的匿名结构体为空
+func JSONName(v *types.Var, tag reflect.StructTag) string {
+	jt := tag.Get("json")
+	if jt == "-" {
+		return ""
+	}
+	if name := strings.Split(jt, ",")[0]; name != "" {
+		return name
+	}
+	if v.Anonymous() && IsStruct(Deref(v.Type())) {
+		return ""
+	}
+	return v.Name()
+}
+
+// IsStruct t 的底层类型为结构体
+func IsStruct(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
@@ -0,0 +1,268 @@
+package gvalid
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/19 11:05
+ * @Desc: 解析 JSON 并验证, 解析错误转换为字段错误
+ */
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ValidateJSON 解析 JSON 到 dst 并验证
+// 类型错误 (如 int64 字段传入字符串) 和未知字段 (DisallowUnknownFields) 转换为对应路径的字段错误, 与验证错误一起放入 Errors,
+// 已有解析错误的字段不再重复报告验证错误
+// JSON 格式错误时返回 err, 根的类型错误 (如结构体传入 []) 不是字段错误, 返回 *json.UnmarshalTypeError
+func (valid *Validation) ValidateJSON(data []byte, dst interface{}) (b bool, err error) {
+	tOf := reflect.TypeOf(dst)
+	if tOf == nil || !isStructPtr(tOf) {
+		err = fmt.Errorf("%v 必须是 结构体指针", dst)
+		return
+	}
+
+	var raw interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&raw); err != nil {
+		return
+	}
+
+	var decodeErrs []*Error
+	checkJSON(raw, tOf, valid.path, "", "", nil, valid.DisallowUnknownFields, &decodeErrs)
+
+	if err = json.Unmarshal(data, dst); err != nil {
+		var te *json.UnmarshalTypeError
+		if !errors.As(err, &te) || te.Field == "" {
+			return
+		}
+		// 自定义 UnmarshalJSON 等情况 checkJSON 检查不到, 按 json 返回的字段转换
+		if len(decodeErrs) == 0 {
+			decodeErrs = append(decodeErrs, typeErrorOf(tOf.Elem(), valid.path, te))
+		}
+		err = nil
+	}
+
+	for _, e := range decodeErrs {
+		valid.setError(e)
+	}
+
+	if _, err = valid.Valid(dst); err != nil {
+		return
+	}
+
+	if len(decodeErrs) > 0 {
+		valid.filterErrors(func(e *Error) bool {
+			if e.Rule == TypeRule || e.Rule == UnknownRule {
+				return true
+			}
+			for _, de := range decodeErrs {
+				if pathHasPrefix(e.Path, de.Path) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	return !valid.HasErrors(), nil
+}
+
+// checkJSON 对照 t 检查 json 值的类型及未知字段, mask 为当前字段的脱敏函数, 用于 Error.Value
+func checkJSON(raw interface{}, t reflect.Type, path, field, name string, mask Masker, disallowUnknown bool, errs *[]*Error) {
+	if raw == nil {
+		return
+	}
+	if t.Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(jsonUnmarshalerType) ||
+		t.Implements(textUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	ok := true
+	switch t.Kind() {
+	case reflect.Interface:
+	case reflect.Struct:
+		obj, isObj := raw.(map[string]interface{})
+		if !isObj {
+			ok = false
+			break
+		}
+		for _, key := range sortedKeys(obj) {
+			val := obj[key]
+			f, goPath, found := jsonField(t, key)
+			if !found {
+				if disallowUnknown {
+					*errs = append(*errs, &Error{Field: key, Name: key, Message: ValidateValUnknownField,
+						Path: joinPath(path, key), Rule: UnknownRule, Value: maskedValue(obj[key], keyMasker(key))})
+				}
+				continue
+			}
+			if jsonStringOption(f) {
+				continue
+			}
+			checkJSON(val, f.Type, joinPath(path, goPath), f.Name, f.Tag.Get(defaultNameTag), structFieldMasker(t, f, nil), disallowUnknown, errs)
+		}
+	case reflect.Map:
+		obj, isObj := raw.(map[string]interface{})
+		if !isObj {
+			ok = false
+			break
+		}
+		for _, key := range sortedKeys(obj) {
+			checkJSON(obj[key], t.Elem(), fmt.Sprintf("%s[%s]", path, key), field, name, mask, disallowUnknown, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			_, ok = raw.(string)
+			break
+		}
+		arr, isArr := raw.([]interface{})
+		if !isArr {
+			ok = false
+			break
+		}
+		for i, val := range arr {
+			checkJSON(val, t.Elem(), fmt.Sprintf("%s[%d]", path, i), field, name, mask, disallowUnknown, errs)
+		}
+	case reflect.String:
+		_, ok = raw.(string)
+	case reflect.Bool:
+		_, ok = raw.(bool)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, isNum := raw.(json.Number)
+		if ok = isNum; ok {
+			_, e := strconv.ParseInt(string(n), 10, t.Bits())
+			ok = e == nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, isNum := raw.(json.Number)
+		if ok = isNum; ok {
+			_, e := strconv.ParseUint(string(n), 10, t.Bits())
+			ok = e == nil
+		}
+	case reflect.Float32, reflect.Float64:
+		n, isNum := raw.(json.Number)
+		if ok = isNum; ok {
+			_, e := strconv.ParseFloat(string(n), t.Bits())
+			ok = e == nil
+		}
+	}
+
+	if !ok {
+		*errs = append(*errs, &Error{Field: field, Name: name, Message: fmt.Sprintf(ValidateValTypeMismatch, t.String()),
+			Path: path, Rule: TypeRule, Param: t.String(), Kind: t.Kind().String(), Value: maskedValue(raw, mask)})
+	}
+}
+
+// sortedKeys 按 key 排序, 保证错误顺序稳定
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// typeErrorOf 将 json.UnmarshalTypeError 转为字段错误
+// te.Field 为 json 字段名路径, 如 gallery.imgUrl, 不含下标
+func typeErrorOf(t reflect.Type, prefix string, te *json.UnmarshalTypeError) *Error {
+	path, f, parent := prefix, reflect.StructField{}, t
+	for _, key := range strings.Split(te.Field, ".") {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			break
+		}
+		sf, goPath, found := jsonField(t, key)
+		if !found {
+			break
+		}
+		f, parent, t, path = sf, t, sf.Type, joinPath(path, goPath)
+	}
+	var mask Masker
+	if f.Name != "" {
+		mask = structFieldMasker(parent, f, nil)
+	}
+	return &Error{Field: f.Name, Name: f.Tag.Get(defaultNameTag), Message: fmt.Sprintf(ValidateValTypeMismatch, te.Type.String()),
+		Path: path, Rule: TypeRule, Param: te.Type.String(), Kind: te.Type.Kind().String(), Value: maskedValue(te.Value, mask)}
+}
+
+// jsonField 按 json 字段名查找结构体字段, 匿名嵌入结构体的字段视为当前结构体的字段
+// 与 encoding/json 一致, 优先完全匹配, 其次忽略大小写匹配
+func jsonField(t reflect.Type, key string) (f reflect.StructField, goPath string, found bool) {
+	fields := jsonFields(t)
+	for _, jf := range fields {
+		if jf.name == key {
+			return jf.field, jf.field.Name, true
+		}
+	}
+	for _, jf := range fields {
+		if strings.EqualFold(jf.name, key) {
+			return jf.field, jf.field.Name, true
+		}
+	}
+	return
+}
+
+type jsonStructField struct {
+	name  string
+	field reflect.StructField
+}
+
+// jsonFields 结构体的 json 字段
+func jsonFields(t reflect.Type) (fields []jsonStructField) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && isStructOrStructPtr(f.Type) {
+			et := f.Type
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+			fields = append(fields, jsonFields(et)...)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonStructField{name, f})
+	}
+	return
+}
+
+// jsonStringOption 是否有 json:",string" 选项
+func jsonStringOption(f reflect.StructField) bool {
+	opts := strings.Split(f.Tag.Get("json"), ",")
+	for _, opt := range opts[1:] {
+		if opt == "string" {
+			return true
+		}
+	}
+	return false
+}
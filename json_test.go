@@ -0,0 +1,103 @@
+package gvalid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/19 11:52
+ * @Desc:
+ */
+
+type jsonImg struct {
+	ImgUrl string `json:"imgUrl" valid:"required" name:"图片地址"`
+}
+
+type jsonSpu struct {
+	Cate    int64      `json:"cate" valid:"required,gt=0" name:"商品分类"`
+	Name    string     `json:"name" valid:"required,lte=10" name:"商品名称"`
+	Gallery *jsonImg   `json:"gallery" valid:"required,dive" name:"图片"`
+	List    []*jsonImg `json:"list" valid:"dive" name:"图片列表"`
+}
+
+type jsonGoods struct {
+	jsonSpu `valid:"dive"`
+	Stock   int `json:"stock" valid:"required,gte=1" name:"库存"`
+}
+
+func TestValidateJSON(t *testing.T) {
+	Convey("valid json", t, func() {
+		input := &jsonGoods{}
+		v := &Validation{}
+		b, err := v.ValidateJSON([]byte(`{"cate":1,"name":"衣服","gallery":{"imgUrl":"a.png"},"stock":1}`), input)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+		So(input.Cate, ShouldEqual, 1)
+	})
+
+	Convey("type errors", t, func() {
+		input := &jsonGoods{}
+		v := &Validation{}
+		b, err := v.ValidateJSON([]byte(`{"cate":"1","name":"衣服","gallery":{"imgUrl":1},"list":[{"imgUrl":"a.png"},{"imgUrl":true}],"stock":1.5}`), input)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(len(v.Errors), ShouldEqual, 4)
+
+		e := v.ErrorsMap["Cate"]
+		So(len(e), ShouldEqual, 1)
+		So(e[0].Rule, ShouldEqual, TypeRule)
+		So(e[0].String(), ShouldEqual, "商品分类 类型错误, 应为 int64")
+		So(v.ErrorsByPath["Gallery.ImgUrl"][0].Rule, ShouldEqual, TypeRule)
+		So(v.ErrorsByPath["List[1].ImgUrl"][0].Name, ShouldEqual, "图片地址")
+		So(v.ErrorsMap["Stock"][0].Rule, ShouldEqual, TypeRule)
+	})
+
+	Convey("type error of nested struct", t, func() {
+		v := &Validation{}
+		b, err := v.ValidateJSON([]byte(`{"cate":1,"name":"衣服","gallery":"a.png","stock":1}`), &jsonGoods{})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(len(v.Errors), ShouldEqual, 1)
+		So(v.Errors[0].Path, ShouldEqual, "Gallery")
+		So(v.Errors[0].Rule, ShouldEqual, TypeRule)
+	})
+
+	Convey("unknown fields", t, func() {
+		input := &jsonGoods{}
+		data := []byte(`{"cate":1,"name":"衣服","gallery":{"imgUrl":"a.png","size":1},"stock":1,"price":1}`)
+
+		v := &Validation{}
+		b, err := v.ValidateJSON(data, input)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+
+		v = &Validation{DisallowUnknownFields: true}
+		b, err = v.ValidateJSON(data, input)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(v.ErrorsMap["price"][0].Rule, ShouldEqual, UnknownRule)
+		So(v.ErrorsByPath["Gallery.size"][0].Rule, ShouldEqual, UnknownRule)
+	})
+
+	Convey("syntax error", t, func() {
+		v := &Validation{}
+		_, err := v.ValidateJSON([]byte(`{"cate":`), &jsonGoods{})
+		So(err, ShouldNotBeNil)
+		So(v.HasErrors(), ShouldBeFalse)
+	})
+
+	Convey("root type error", t, func() {
+		v := &Validation{}
+		_, err := v.ValidateJSON([]byte(`[]`), &jsonGoods{})
+		var te *json.UnmarshalTypeError
+		So(errors.As(err, &te), ShouldBeTrue)
+		So(te.Value, ShouldEqual, "array")
+		So(v.HasErrors(), ShouldBeFalse)
+	})
+}
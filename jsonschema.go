@@ -0,0 +1,481 @@
+package gvalid
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/22 10:00
+ * @Desc: 根据 valid tag 生成 JSON Schema (Draft 2020-12)
+ */
+
+// JSONSchemaDraft JSONSchema 生成的 $schema
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema JSON Schema, 只包含 valid tag 能表达的关键字
+type Schema struct {
+	Schema           string             `json:"$schema,omitempty"`
+	Ref              string             `json:"$ref,omitempty"`
+	Title            string             `json:"title,omitempty"`
+	Type             string             `json:"type,omitempty"`
+	Format           string             `json:"format,omitempty"`
+	Pattern          string             `json:"pattern,omitempty"`
+	ContentEncoding  string             `json:"contentEncoding,omitempty"`
+	Enum             []interface{}      `json:"enum,omitempty"`
+	Default          interface{}        `json:"default,omitempty"`
+	Minimum          *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum *float64           `json:"exclusiveMinimum,omitempty"`
+	Maximum          *float64           `json:"maximum,omitempty"`
+	ExclusiveMaximum *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength        *int               `json:"minLength,omitempty"`
+	MaxLength        *int               `json:"maxLength,omitempty"`
+	MinItems         *int               `json:"minItems,omitempty"`
+	MaxItems         *int               `json:"maxItems,omitempty"`
+	UniqueItems      bool               `json:"uniqueItems,omitempty"`
+	MinProperties    *int               `json:"minProperties,omitempty"`
+	MaxProperties    *int               `json:"maxProperties,omitempty"`
+	Items            *Schema            `json:"items,omitempty"`
+	Properties       map[string]*Schema `json:"properties,omitempty"`
+	Required         []string           `json:"required,omitempty"`
+	// AdditionalProperties map 的值
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	// Nullable 可以为 null, type 输出为 [type, "null"]
+	Nullable bool `json:"-"`
+	// Extensions x- 开头的扩展字段, 没有对应关键字的规则, 只在 OpenAPI 中生成
+	Extensions map[string]interface{} `json:"-"`
+}
+
+type schemaJSON Schema
+
+// MarshalJSON 输出 Nullable 和 Extensions
+func (s Schema) MarshalJSON() ([]byte, error) {
+	var v interface{} = (*schemaJSON)(&s)
+	if s.Nullable && s.Type != "" {
+		nullable := struct {
+			*schemaJSON
+			Type []string      `json:"type"`
+			Enum []interface{} `json:"enum,omitempty"`
+		}{schemaJSON: (*schemaJSON)(&s), Type: []string{s.Type, "null"}}
+		if len(s.Enum) > 0 {
+			nullable.Enum = append(append(nullable.Enum, s.Enum...), nil)
+		}
+		v = nullable
+	}
+	b, err := json.Marshal(v)
+	if err != nil || len(s.Extensions) == 0 {
+		return b, err
+	}
+	ext, err := json.Marshal(s.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 2 {
+		return ext, nil
+	}
+	b[len(b)-1] = ','
+	return append(b, ext[1:]...), nil
+}
+
+// JSONSchema 根据 valid tag 生成 obj 的 JSON Schema, obj 为结构体或结构体指针
+// 字段名同 encoding/json, name tag 为 title, dive 的结构体在 $defs 中; 只包含没有分组的规则, 同 Groups 为空时的验证
+// 注意: 除 required 外的规则不验证零值, JSON Schema 中无法表达, 如 gt=2 的字符串 "" 能通过验证, 但不满足 minLength
+func JSONSchema(obj interface{}) (*Schema, error) {
+	ts, err := DescribeType(reflect.TypeOf(obj))
+	if err != nil {
+		return nil, err
+	}
+	b := newSchemaBuilder("#/$defs/")
+	b.root = ts.Type
+	s := b.object(ts)
+	s.Schema, s.Title = JSONSchemaDraft, ts.Name
+	if len(b.defs) > 0 {
+		s.Defs = b.defs
+	}
+	return s, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type schemaBuilder struct {
+	// root 引用为 # 的顶层结构体
+	root reflect.Type
+	// refPrefix 引用 defs 的前缀
+	refPrefix string
+	defs      map[string]*Schema
+	// names 结构体在 defs 中的名称
+	names map[reflect.Type]string
+	// extensions 是否为没有对应关键字的规则生成 x-gvalid-规则名
+	extensions bool
+}
+
+func newSchemaBuilder(refPrefix string) *schemaBuilder {
+	return &schemaBuilder{refPrefix: refPrefix, defs: make(map[string]*Schema), names: make(map[reflect.Type]string)}
+}
+
+// object 结构体的 schema
+func (b *schemaBuilder) object(ts *TypeSchema) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	b.properties(s, ts, true, &propertyWalk{depths: make(map[string]int), visiting: map[*TypeSchema]bool{ts: true}})
+	return s
+}
+
+// properties 添加字段, 同 encoding/json 展开匿名结构体的字段, 不论是否有 dive
+// validated 为 false 时字段不验证 (没有 dive 的匿名结构体), 只描述字段的类型
+func (b *schemaBuilder) properties(s *Schema, ts *TypeSchema, validated bool, walk *propertyWalk) {
+	walk.depth++
+	defer func() { walk.depth-- }()
+	for _, fs := range ts.Fields {
+		if fs.JSONName == "" {
+			// 指针嵌入自身时 encoding/json 同样忽略
+			if fs.Anonymous && fs.Dive != nil && !walk.visiting[fs.Dive] {
+				walk.visiting[fs.Dive] = true
+				b.properties(s, fs.Dive, validated && fs.Rules.Has(ruleName(diveFunc)), walk)
+				delete(walk.visiting, fs.Dive)
+			}
+			continue
+		}
+		// 同名的字段层级浅的优先, 如外层结构体的字段
+		if depth, ok := walk.depths[fs.JSONName]; ok {
+			if depth <= walk.depth {
+				continue
+			}
+			s.Required = removeString(s.Required, fs.JSONName)
+		}
+		walk.depths[fs.JSONName] = walk.depth
+		f, _ := ts.Type.FieldByName(fs.Name)
+		if !validated {
+			fs = &FieldSchema{Name: fs.Name, Label: fs.Label, JSONName: fs.JSONName, Type: fs.Type, Anonymous: fs.Anonymous}
+		}
+		s.Properties[fs.JSONName] = b.field(fs, f)
+		if hasRequired(fs.Rules) {
+			s.Required = append(s.Required, fs.JSONName)
+		}
+	}
+}
+
+// propertyWalk 展开匿名结构体时的状态
+type propertyWalk struct {
+	depth int
+	// depths 已添加的字段所在的层级
+	depths   map[string]int
+	visiting map[*TypeSchema]bool
+}
+
+// removeString 去除 list 中的 s
+func removeString(list []string, s string) []string {
+	var out []string
+	for _, e := range list {
+		if e != s {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// field 字段的 schema
+func (b *schemaBuilder) field(fs *FieldSchema, f reflect.StructField) *Schema {
+	t := indirectType(fs.Type)
+	s := b.typeSchema(t, fs.Dive)
+	switch {
+	// 自定义类型的 JSON 格式未知
+	case NewFieldType(t).Custom:
+		s = &Schema{}
+	// json:",string" 的数字以字符串传输, 数字的规则不再适用
+	case jsonStringOption(f):
+		return &Schema{Type: "string", Title: fs.Label}
+	}
+	s.Title = fs.Label
+	for _, r := range fs.Rules {
+		if len(r.Groups) == 0 {
+			b.rule(s, r, t)
+		}
+	}
+	// 没有 required 的指针可以为 null
+	if fs.Type.Kind() == reflect.Ptr && !hasRequired(fs.Rules) {
+		if s.Ref != "" {
+			s = &Schema{Title: s.Title, AnyOf: []*Schema{{Ref: s.Ref}, {Type: "null"}}}
+		} else {
+			s.Nullable = s.Type != ""
+		}
+	}
+	return s
+}
+
+// hasRequired 是否有不分组的 required
+func hasRequired(rules RuleSet) bool {
+	for _, r := range rules {
+		if r.Name == "required" && len(r.Groups) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// typeSchema 类型的 schema, dive 为结构体或 slice 元素的验证规则
+func (b *schemaBuilder) typeSchema(t reflect.Type, dive *TypeSchema) *Schema {
+	t = indirectType(t)
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		// []byte 以 base64 字符串传输
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &Schema{Type: "array", Items: b.typeSchema(t.Elem(), dive)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.typeSchema(t.Elem(), nil)}
+	case reflect.Struct:
+		if dive != nil {
+			return b.ref(dive)
+		}
+		return &Schema{Type: "object"}
+	}
+	return &Schema{}
+}
+
+// ref dive 的结构体, 引用 defs 中的定义, 顶层结构体为 #
+func (b *schemaBuilder) ref(ts *TypeSchema) *Schema {
+	if ts.Type == b.root {
+		return &Schema{Ref: "#"}
+	}
+	name, ok := b.names[ts.Type]
+	if !ok {
+		name = uniqueTypeName(ts, func(name string) bool {
+			_, ok := b.defs[name]
+			return ok
+		})
+		b.names[ts.Type] = name
+		// 先占位, 递归的类型引用自身
+		def := &Schema{}
+		b.defs[name] = def
+		*def = *b.object(ts)
+		def.Title = ts.Name
+	}
+	return &Schema{Ref: b.refPrefix + name}
+}
+
+// uniqueTypeName 结构体的名称, 重名或匿名结构体时为 包名_类型名
+func uniqueTypeName(ts *TypeSchema, exists func(name string) bool) string {
+	if ts.Name == "" || exists(ts.Name) {
+		return strings.NewReplacer(".", "_", " ", "").Replace(ts.Type.String())
+	}
+	return ts.Name
+}
+
+// rule 将规则转换为 JSON Schema 的关键字
+func (b *schemaBuilder) rule(s *Schema, r Rule, t reflect.Type) {
+	switch r.Name {
+	case "required":
+		switch s.Type {
+		case "string":
+			s.MinLength = maxInt(s.MinLength, 1)
+		case "array":
+			s.MinItems = maxInt(s.MinItems, 1)
+		}
+	case "gt", "gte", "lt", "lte", "len":
+		b.bound(s, r)
+	case "in":
+		s.Enum = enumParams(s.Type, r)
+	case "sin":
+		if s.Items != nil {
+			s.Items.Enum = enumParams(s.Items.Type, r)
+		}
+	case toLowerCamel(RegexFunc):
+		addPattern(s, r.Param())
+	case "numeric":
+		addPattern(s, "^[0-9]*$")
+	case "email":
+		s.Format = "email"
+	case "url":
+		s.Format = "uri"
+	case "ip":
+		s.Format = "ipv4"
+	case "base64":
+		s.ContentEncoding = "base64"
+	case "date":
+		dateSchema(s, r.Param())
+	case "distinct":
+		s.UniqueItems = true
+	case "default":
+		if s.Type == "integer" {
+			if v, err := strconv.ParseInt(r.Param(), 10, 64); err == nil {
+				s.Default = v
+			}
+		} else {
+			s.Default = r.Param()
+		}
+	case "enum":
+		name := r.Param()
+		target := s
+		if s.Items != nil {
+			target = s.Items
+		}
+		if name == "" {
+			et := t
+			if et.Kind() == reflect.Slice || et.Kind() == reflect.Array {
+				et = indirectType(et.Elem())
+			}
+			name = et.Name()
+		}
+		if values, ok := EnumValues(name); ok {
+			target.Enum = values
+		}
+	case ruleName(diveFunc):
+	default:
+		if b.extensions {
+			b.extension(s, r)
+		}
+	}
+}
+
+// extension 没有对应关键字的规则, 如 mobile, idCard, 生成 x-gvalid-规则名, 值为参数, 没有参数时为 true
+func (b *schemaBuilder) extension(s *Schema, r Rule) {
+	if s.Extensions == nil {
+		s.Extensions = make(map[string]interface{})
+	}
+	var v interface{} = true
+	switch values := r.Values(); len(values) {
+	case 0:
+	case 1:
+		v = values[0]
+	default:
+		v = values
+	}
+	s.Extensions["x-gvalid-"+r.Name] = v
+}
+
+// bound gt, gte, lt, lte, len, 数字为取值范围, 字符串为字符数, slice 为元素个数, map 为键值对个数
+func (b *schemaBuilder) bound(s *Schema, r Rule) {
+	if s.Type == "number" || s.Type == "integer" {
+		v, err := strconv.ParseFloat(r.Param(), 64)
+		if err != nil {
+			return
+		}
+		switch r.Name {
+		case "gt":
+			s.ExclusiveMinimum = &v
+		case "gte":
+			s.Minimum = &v
+		case "lt":
+			s.ExclusiveMaximum = &v
+		case "lte":
+			s.Maximum = &v
+		}
+		return
+	}
+
+	n, err := strconv.Atoi(r.Param())
+	if err != nil {
+		return
+	}
+	var min, max **int
+	switch s.Type {
+	case "string":
+		min, max = &s.MinLength, &s.MaxLength
+	case "array":
+		min, max = &s.MinItems, &s.MaxItems
+	case "object":
+		min, max = &s.MinProperties, &s.MaxProperties
+	default:
+		return
+	}
+	switch r.Name {
+	case "gt":
+		*min = intPtr(n + 1)
+	case "gte":
+		*min = intPtr(n)
+	case "lt":
+		if n > 0 {
+			*max = intPtr(n - 1)
+		}
+	case "lte":
+		*max = intPtr(n)
+	case "len":
+		*min, *max = intPtr(n), intPtr(n)
+	}
+}
+
+// enumParams in, sin 的参数, 整数类型的参数转为数字
+func enumParams(typ string, r Rule) []interface{} {
+	values := make([]interface{}, len(r.Params))
+	for i, p := range r.Params {
+		values[i] = p.Value
+		if typ == "integer" {
+			if v, err := p.Int(); err == nil {
+				values[i] = v
+			}
+		}
+	}
+	return values
+}
+
+// addPattern 已有 pattern 时使用 allOf 同时满足
+func addPattern(s *Schema, pattern string) {
+	if s.Pattern == "" {
+		s.Pattern = pattern
+		return
+	}
+	s.AllOf = append(s.AllOf, &Schema{Pattern: pattern})
+}
+
+// layoutPatterns 时间格式中的数字对应的正则
+var layoutPatterns = strings.NewReplacer(
+	"2006", `\d{4}`, "01", `\d{2}`, "02", `\d{2}`, "15", `\d{2}`, "04", `\d{2}`, "05", `\d{2}`,
+)
+
+var layoutUnsupported = regexp.MustCompile(`[0-9A-Za-z]`)
+
+// dateSchema date 的格式, 2006-01-02 和 RFC3339 使用 format, 其它只包含数字的格式转为 pattern
+func dateSchema(s *Schema, layout string) {
+	switch layout {
+	case "2006-01-02":
+		s.Format = "date"
+		return
+	case time.RFC3339:
+		s.Format = "date-time"
+		return
+	}
+	parts := strings.SplitAfter(layoutPatterns.Replace(regexp.QuoteMeta(layout)), `}`)
+	for _, part := range parts {
+		literal := part
+		if i := strings.Index(part, `\d{`); i >= 0 {
+			literal = part[:i]
+		}
+		if layoutUnsupported.MatchString(literal) {
+			return
+		}
+	}
+	addPattern(s, "^"+strings.Join(parts, "")+"$")
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func maxInt(p *int, n int) *int {
+	if p != nil && *p > n {
+		return p
+	}
+	return &n
+}
@@ -0,0 +1,140 @@
+package gvalid
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/22 10:40
+ * @Desc:
+ */
+
+type schemaCategory struct {
+	Name     string            `json:"name" valid:"required,lte=10" name:"分类名称"`
+	Children []*schemaCategory `json:"children" valid:"dive"`
+	Parent   *schemaCategory   `json:"parent" valid:"dive"`
+}
+
+type schemaOrder struct {
+	Title    string         `json:"title" valid:"required,gt=2,lt=20" name:"标题"`
+	Price    float64        `json:"price" valid:"gt=0,lte=100.5"`
+	Count    int            `json:"count,string" valid:"gte=1"`
+	Kind     string         `json:"kind" valid:"in='a b' c,default=c"`
+	Level    int8           `json:"level" valid:"in=1 2,default=1"`
+	Tags     []string       `json:"tags" valid:"sin=new hot,distinct,len=2"`
+	Code     string         `json:"code" valid:"regex=(/^[A-Z]+$/),numeric"`
+	Email    string         `json:"email" valid:"email"`
+	Site     string         `json:"site" valid:"url"`
+	Ip       string         `json:"ip" valid:"ip"`
+	Date     string         `json:"date" valid:"date=2006-01-02"`
+	Time     string         `json:"time" valid:"date=2006/01/02 15:04"`
+	Month    string         `json:"month" valid:"date=Jan 2006"`
+	Status   OrderStatus    `json:"status" valid:"enum"`
+	History  []OrderStatus  `json:"history" valid:"enum=OrderStatus"`
+	Remark   sql.NullString `json:"remark" valid:"lte=5"`
+	Meta     map[string]int `json:"meta" valid:"lte=3"`
+	Password string         `json:"password" valid:"required@create,gte=6@create"`
+	Ignored  string         `json:"-" valid:"required"`
+	Category schemaCategory `json:"category" valid:"dive"`
+}
+
+type schemaSpu struct {
+	Name  string `json:"name" valid:"required" name:"商品名称"`
+	Price int64  `json:"price" valid:"gt=0"`
+}
+
+// schemaSpuGoods 匿名结构体没有 dive, 字段不验证, 同 encoding/json 属于外层结构体
+type schemaSpuGoods struct {
+	schemaSpu `valid:"required"`
+	Price     string `json:"price"`
+	Cate      int64  `json:"cate" valid:"required"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	Convey("test json schema of embedded and dive structs", t, func() {
+		s, err := JSONSchema(&jsonGoods{})
+		So(err, ShouldBeNil)
+		b, err := json.Marshal(s)
+		So(err, ShouldBeNil)
+		So(string(b), ShouldEqual, `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"jsonGoods","type":"object",`+
+			`"properties":{"cate":{"title":"商品分类","type":"integer","exclusiveMinimum":0},`+
+			`"gallery":{"$ref":"#/$defs/jsonImg","title":"图片"},`+
+			`"list":{"title":"图片列表","type":"array","items":{"$ref":"#/$defs/jsonImg"}},`+
+			`"name":{"title":"商品名称","type":"string","minLength":1,"maxLength":10},`+
+			`"stock":{"title":"库存","type":"integer","minimum":1}},`+
+			`"required":["cate","name","gallery","stock"],`+
+			`"$defs":{"jsonImg":{"title":"jsonImg","type":"object","properties":{"imgUrl":{"title":"图片地址","type":"string","minLength":1}},"required":["imgUrl"]}}}`)
+	})
+
+	Convey("test json schema of embedded structs without dive", t, func() {
+		s, err := JSONSchema(schemaSpuGoods{})
+		So(err, ShouldBeNil)
+		So(s.Properties, ShouldResemble, map[string]*Schema{
+			"name":  {Title: "商品名称", Type: "string"},
+			"price": {Type: "string"},
+			"cate":  {Type: "integer"},
+		})
+		So(s.Required, ShouldResemble, []string{"cate"})
+	})
+
+	Convey("test json schema rules", t, func() {
+		RegisterEnum("OrderStatus", OrderStatusPaid, OrderStatusShipped, OrderStatusDone)
+		defer delete(enums, "OrderStatus")
+
+		s, err := JSONSchema(schemaOrder{})
+		So(err, ShouldBeNil)
+		So(s.Required, ShouldResemble, []string{"title"})
+		p := s.Properties
+		So(p, ShouldNotContainKey, "Ignored")
+
+		So(p["title"].Title, ShouldEqual, "标题")
+		So(*p["title"].MinLength, ShouldEqual, 3)
+		So(*p["title"].MaxLength, ShouldEqual, 19)
+		So(*p["price"].ExclusiveMinimum, ShouldEqual, 0)
+		So(*p["price"].Maximum, ShouldEqual, 100.5)
+		So(p["count"], ShouldResemble, &Schema{Type: "string"})
+		So(p["kind"].Enum, ShouldResemble, []interface{}{"a b", "c"})
+		So(p["kind"].Default, ShouldEqual, "c")
+		So(p["level"].Enum, ShouldResemble, []interface{}{int64(1), int64(2)})
+		So(p["level"].Default, ShouldEqual, int64(1))
+		So(p["tags"].Items.Enum, ShouldResemble, []interface{}{"new", "hot"})
+		So(p["tags"].UniqueItems, ShouldBeTrue)
+		So(*p["tags"].MinItems, ShouldEqual, 2)
+		So(*p["tags"].MaxItems, ShouldEqual, 2)
+		So(p["code"].Pattern, ShouldEqual, "^[A-Z]+$")
+		So(p["code"].AllOf, ShouldResemble, []*Schema{{Pattern: "^[0-9]*$"}})
+		So(p["email"].Format, ShouldEqual, "email")
+		So(p["site"].Format, ShouldEqual, "uri")
+		So(p["ip"].Format, ShouldEqual, "ipv4")
+		So(p["date"].Format, ShouldEqual, "date")
+		So(p["time"].Pattern, ShouldEqual, `^\d{4}/\d{2}/\d{2} \d{2}:\d{2}$`)
+		So(p["month"].Pattern, ShouldEqual, "")
+		So(p["status"].Enum, ShouldResemble, []interface{}{OrderStatusPaid, OrderStatusShipped, OrderStatusDone})
+		So(p["history"].Items.Enum, ShouldResemble, []interface{}{OrderStatusPaid, OrderStatusShipped, OrderStatusDone})
+		So(p["remark"], ShouldResemble, &Schema{})
+		So(*p["meta"].MaxProperties, ShouldEqual, 3)
+		So(p["meta"].AdditionalProperties.Type, ShouldEqual, "integer")
+		// 分组的规则不导出
+		So(p["password"], ShouldResemble, &Schema{Type: "string"})
+
+		So(p["category"].Ref, ShouldEqual, "#/$defs/schemaCategory")
+		category := s.Defs["schemaCategory"]
+		So(category.Properties["children"].Items.Ref, ShouldEqual, "#/$defs/schemaCategory")
+		// 没有 required 的指针可以为 null
+		So(category.Properties["parent"].AnyOf, ShouldResemble, []*Schema{{Ref: "#/$defs/schemaCategory"}, {Type: "null"}})
+
+		s, err = JSONSchema(schemaCategory{})
+		So(err, ShouldBeNil)
+		So(s.Properties["parent"].AnyOf[0].Ref, ShouldEqual, "#")
+		So(s.Defs, ShouldBeNil)
+
+		_, err = JSONSchema(1)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,60 @@
+package gvalid
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/22 15:00
+ * @Desc: 根据 valid tag 生成 OpenAPI 3.1 的 components.schemas
+ */
+
+// OpenAPIRefPrefix components.schemas 的引用前缀
+const OpenAPIRefPrefix = "#/components/schemas/"
+
+var openAPITypes []reflect.Type
+
+// RegisterOpenAPISchema 注册生成 OpenAPI components 的结构体, 如请求和响应的 DTO, objs 为结构体或结构体指针
+func RegisterOpenAPISchema(objs ...interface{}) {
+	for _, obj := range objs {
+		openAPITypes = append(openAPITypes, reflect.TypeOf(obj))
+	}
+}
+
+// OpenAPIComponents OpenAPI 的 components, 只包含 schemas
+type OpenAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// OpenAPISchemas 生成已注册结构体及其 dive 的结构体的 components.schemas, 名称为类型名, 重名时为包名_类型名
+// OpenAPI 3.1 的 Schema 即 JSON Schema Draft 2020-12, 规则同 JSONSchema, 另外:
+// 没有 required 的指针可以为 null; 没有对应关键字的规则, 如 mobile, idCard, 生成 x-gvalid-规则名 扩展字段
+func OpenAPISchemas() (*OpenAPIComponents, error) {
+	b := newSchemaBuilder(OpenAPIRefPrefix)
+	b.extensions = true
+	for _, t := range openAPITypes {
+		ts, err := DescribeType(t)
+		if err != nil {
+			return nil, err
+		}
+		b.ref(ts)
+	}
+	return &OpenAPIComponents{Schemas: b.defs}, nil
+}
+
+// JSON 输出 {"components": {"schemas": ...}}
+func (c *OpenAPIComponents) JSON() ([]byte, error) {
+	return json.MarshalIndent(map[string]interface{}{"components": c}, "", "  ")
+}
+
+// YAML 输出 components: schemas: ..., 可以直接合并到 OpenAPI 文档中
+func (c *OpenAPIComponents) YAML() ([]byte, error) {
+	b, err := json.Marshal(map[string]interface{}{"components": c})
+	if err != nil {
+		return nil, err
+	}
+	return jsonToYAML(b)
+}
@@ -0,0 +1,173 @@
+package gvalid
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/22 16:00
+ * @Desc:
+ */
+
+type apiUser struct {
+	Mobile string  `json:"mobile" valid:"required,mobile" name:"手机号"`
+	IdCard string  `json:"idCard" valid:"idCard,sensitive=idCard"`
+	Age    *int    `json:"age" valid:"gte=18,in=18 19"`
+	Nick   *string `json:"nick" valid:"required"`
+}
+
+type apiRequest struct {
+	jsonGoods
+	User  *apiUser `json:"user" valid:"dive"`
+	Owner apiUser  `json:"owner" valid:"dive"`
+}
+
+type apiGoodsSpuBase struct {
+	Cate    int64    `json:"cate" valid:"required,gt=0" name:"商品分类"`
+	Name    string   `json:"name" valid:"required,lte=10" name:"商品名称"`
+	Gallery *jsonImg `json:"gallery" valid:"required,dive" name:"图片"`
+}
+
+type apiGoodsSkuBase struct {
+	SellingPrice float64 `json:"sellingPrice" valid:"required,gt=0" name:"销售价格"`
+	Stock        int     `json:"stock" valid:"required,gte=1" name:"库存"`
+}
+
+// apiGoodsBase 匿名结构体没有 dive
+type apiGoodsBase struct {
+	apiGoodsSpuBase
+	apiGoodsSkuBase `valid:"required"`
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	Convey("test openapi components", t, func() {
+		defer func() { openAPITypes = nil }()
+		RegisterOpenAPISchema(&apiRequest{}, apiUser{})
+
+		c, err := OpenAPISchemas()
+		So(err, ShouldBeNil)
+		So(c.Schemas, ShouldContainKey, "apiRequest")
+		So(c.Schemas, ShouldContainKey, "apiUser")
+		So(c.Schemas, ShouldContainKey, "jsonImg")
+
+		req := c.Schemas["apiRequest"]
+		// 匿名结构体的字段展开
+		So(req.Properties, ShouldContainKey, "cate")
+		So(req.Properties, ShouldContainKey, "stock")
+		So(req.Properties["gallery"].Ref, ShouldEqual, "#/components/schemas/jsonImg")
+		So(req.Properties["user"].AnyOf, ShouldResemble, []*Schema{{Ref: "#/components/schemas/apiUser"}, {Type: "null"}})
+		So(req.Properties["owner"].Ref, ShouldEqual, "#/components/schemas/apiUser")
+
+		b, err := json.Marshal(c.Schemas["apiUser"])
+		So(err, ShouldBeNil)
+		So(string(b), ShouldEqual, `{"title":"apiUser","type":"object","properties":{`+
+			`"age":{"minimum":18,"type":["integer","null"],"enum":[18,19,null]},`+
+			`"idCard":{"type":"string","x-gvalid-idCard":true,"x-gvalid-sensitive":"idCard"},`+
+			`"mobile":{"title":"手机号","type":"string","minLength":1,"x-gvalid-mobile":true},`+
+			`"nick":{"type":"string","minLength":1}},"required":["mobile","nick"]}`)
+
+		RegisterOpenAPISchema(1)
+		_, err = OpenAPISchemas()
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("test openapi components of embedded structs without dive", t, func() {
+		defer func() { openAPITypes = nil }()
+		RegisterOpenAPISchema(apiGoodsBase{})
+
+		c, err := OpenAPISchemas()
+		So(err, ShouldBeNil)
+		b, err := json.Marshal(c.Schemas["apiGoodsBase"])
+		So(err, ShouldBeNil)
+		So(string(b), ShouldEqual, `{"title":"apiGoodsBase","type":"object","properties":{`+
+			`"cate":{"title":"商品分类","type":"integer","exclusiveMinimum":0},`+
+			`"gallery":{"$ref":"#/components/schemas/jsonImg","title":"图片"},`+
+			`"name":{"title":"商品名称","type":"string","minLength":1,"maxLength":10},`+
+			`"sellingPrice":{"title":"销售价格","type":"number"},`+
+			`"stock":{"title":"库存","type":"integer"}},`+
+			`"required":["cate","name","gallery"]}`)
+		So(c.Schemas, ShouldContainKey, "jsonImg")
+	})
+
+	Convey("test openapi yaml", t, func() {
+		defer func() { openAPITypes = nil }()
+		RegisterOpenAPISchema(jsonImg{}, apiUser{})
+
+		c, err := OpenAPISchemas()
+		So(err, ShouldBeNil)
+		b, err := c.YAML()
+		So(err, ShouldBeNil)
+		So(string(b), ShouldEqual, `components:
+  schemas:
+    apiUser:
+      title: "apiUser"
+      type: "object"
+      properties:
+        age:
+          minimum: 18
+          type:
+            - "integer"
+            - "null"
+          enum:
+            - 18
+            - 19
+            - null
+        idCard:
+          type: "string"
+          x-gvalid-idCard: true
+          x-gvalid-sensitive: "idCard"
+        mobile:
+          title: "手机号"
+          type: "string"
+          minLength: 1
+          x-gvalid-mobile: true
+        nick:
+          type: "string"
+          minLength: 1
+      required:
+        - "mobile"
+        - "nick"
+    jsonImg:
+      title: "jsonImg"
+      type: "object"
+      properties:
+        imgUrl:
+          title: "图片地址"
+          type: "string"
+          minLength: 1
+      required:
+        - "imgUrl"
+`)
+
+		b, err = c.JSON()
+		So(err, ShouldBeNil)
+		var doc map[string]map[string]map[string]interface{}
+		So(json.Unmarshal(b, &doc), ShouldBeNil)
+		So(doc["components"]["schemas"], ShouldContainKey, "jsonImg")
+	})
+}
+
+func TestJSONToYAML(t *testing.T) {
+	Convey("test json to yaml", t, func() {
+		b, err := jsonToYAML([]byte(`{"b":[{"x":1,"y":[]},[1,2]],"a":{},"true":"a\"b\n","$ref":null,"a b":1.5}`))
+		So(err, ShouldBeNil)
+		So(string(b), ShouldEqual, `b:
+  - x: 1
+    "y": []
+  - - 1
+    - 2
+a: {}
+"true": "a\"b\n"
+$ref: null
+"a b": 1.5
+`)
+
+		_, err = jsonToYAML([]byte(`{"a":`))
+		So(err, ShouldNotBeNil)
+	})
+}
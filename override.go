@@ -0,0 +1,272 @@
+package gvalid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/25 10:00
+ * @Desc: 从 YAML 或 JSON 加载字段规则的覆盖, 无需重新部署即可调整限制
+ */
+
+// ruleOverrides 加载后的覆盖规则, 加载后不再修改
+type ruleOverrides map[reflect.Type]map[string]RuleSet
+
+var (
+	overrideTypesMu sync.RWMutex
+	// overrideTypes 类型名及 包名.类型名 对应的类型
+	overrideTypes = make(map[string][]reflect.Type)
+
+	// currentOverrides 当前的覆盖规则, *ruleOverrides
+	currentOverrides atomic.Value
+)
+
+func init() {
+	currentOverrides.Store(&ruleOverrides{})
+}
+
+// RegisterOverrideTypes 注册可以被覆盖规则的类型, types 为结构体或结构体指针
+// 文档中使用类型名 (如 GoodsBase) 或 包名.类型名 (如 dto.GoodsBase) 引用, 类型名重复时须使用后者
+func RegisterOverrideTypes(types ...interface{}) {
+	overrideTypesMu.Lock()
+	defer overrideTypesMu.Unlock()
+	for _, obj := range types {
+		t := indirectType(reflect.TypeOf(obj))
+		for _, name := range []string{t.Name(), t.String()} {
+			if !containsType(overrideTypes[name], t) {
+				overrideTypes[name] = append(overrideTypes[name], t)
+			}
+		}
+	}
+}
+
+func containsType(ts []reflect.Type, t reflect.Type) bool {
+	for _, e := range ts {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadOverrides 加载覆盖规则, data 为 YAML 或 JSON 对象, 格式为 类型名 => 字段名 => 规则, 如
+//
+//	GoodsBase:
+//	  Summary: required,lte=200
+//	  Status: in=1 2 3
+//
+// 以 { 开头的为 JSON, 否则为 YAML, YAML 只支持块格式的对象及字符串, 其他语法返回错误
+// 规则的优先级从低到高为 tag, Rules 注册的规则, 覆盖规则, 同名的规则以优先级高的为准, 不同名的规则合并
+// 规则为 - 时删除该字段的所有规则
+// 加载时检查类型, 字段及规则, 有错误时返回 CompileErrors 并保留之前的覆盖规则
+// 成功时原子地替换之前加载的全部覆盖规则, 已开始的验证仍使用之前的规则, gvalid-gen 生成的代码不受影响
+func LoadOverrides(data []byte) error {
+	ov, err := parseOverrides(data)
+	if err != nil {
+		return err
+	}
+	currentOverrides.Store(&ov)
+	return nil
+}
+
+// LoadOverridesFile 从文件加载覆盖规则, 同 LoadOverrides
+func LoadOverridesFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return LoadOverrides(data)
+}
+
+// ResetOverrides 清除所有覆盖规则
+func ResetOverrides() {
+	currentOverrides.Store(&ruleOverrides{})
+}
+
+// WatchOverridesFile 每隔 interval 检查文件, 修改后重新加载, 直到 ctx 结束
+// 启动时先加载一次并返回其错误, 之后加载失败时调用 onError, 保留之前的覆盖规则
+// 加载失败的文件 (如写入一半) 在之后每次检查时重试, 直到加载成功, 相同的错误只调用一次 onError
+func WatchOverridesFile(ctx context.Context, path string, interval time.Duration, onError func(error)) error {
+	var modTime time.Time
+	var size int64 = -1
+	var lastErr string
+	reload := func() error {
+		fi, err := os.Stat(path)
+		if err == nil && fi.ModTime().Equal(modTime) && fi.Size() == size {
+			return nil
+		}
+		if err == nil {
+			if err = LoadOverridesFile(path); err == nil {
+				modTime, size, lastErr = fi.ModTime(), fi.Size(), ""
+				return nil
+			}
+		}
+		if err.Error() == lastErr {
+			return nil
+		}
+		lastErr = err.Error()
+		return err
+	}
+
+	err := reload()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := reload(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+	return err
+}
+
+// loadOverrides 当前的覆盖规则
+func loadOverrides() *ruleOverrides {
+	return currentOverrides.Load().(*ruleOverrides)
+}
+
+// overrideRules 合并字段的覆盖规则, 规则为 - 时返回 nil
+func (ov *ruleOverrides) overrideRules(t reflect.Type, name string, rules RuleSet) RuleSet {
+	if ov == nil {
+		return rules
+	}
+	override, ok := (*ov)[t][name]
+	if !ok {
+		return rules
+	}
+	if len(override) == 0 {
+		return nil
+	}
+	return mergeRules(rules, override)
+}
+
+// parseOverrides 解析并检查覆盖规则, 以 { 开头的为 JSON, 否则为 YAML
+func parseOverrides(data []byte) (ruleOverrides, error) {
+	doc, err := decodeOverrides(data)
+	if err != nil {
+		return nil, err
+	}
+
+	overrideTypesMu.RLock()
+	defer overrideTypesMu.RUnlock()
+	var errs CompileErrors
+	ov := make(ruleOverrides)
+	for _, te := range doc {
+		t, err := overrideType(te.key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fields, ok := te.value.(orderedMap)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: 值必须是 字段名 => 规则", te.key))
+			continue
+		}
+		if ov[t] == nil {
+			ov[t] = make(map[string]RuleSet)
+		}
+		for _, fe := range fields {
+			rules, err := overrideField(t, te.key, fe)
+			if err != nil {
+				errs = append(errs, err...)
+				continue
+			}
+			ov[t][fe.key] = rules
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return ov, nil
+}
+
+// decodeOverrides 解码 JSON 或 YAML 文档, 保持键的顺序
+func decodeOverrides(data []byte) (orderedMap, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return parseYAML(data)
+	}
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+	v, err := decodeOrderedJSON(dec)
+	if err != nil {
+		return nil, fmt.Errorf("json: %v", err)
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return nil, errors.New("json: 覆盖规则必须是单个 JSON 对象")
+	}
+	return v.(orderedMap), nil
+}
+
+// overrideType 类型名对应的类型
+func overrideType(name string) (reflect.Type, error) {
+	ts := overrideTypes[name]
+	switch len(ts) {
+	case 0:
+		return nil, fmt.Errorf("%s: 类型未通过 RegisterOverrideTypes 注册", name)
+	case 1:
+		return ts[0], nil
+	}
+	names := make([]string, len(ts))
+	for i, t := range ts {
+		names[i] = t.String()
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("%s: 类型名重复, 请使用 %v 其中一个", name, names)
+}
+
+// overrideField 解析并检查字段的覆盖规则, 规则为 - 时返回空的 RuleSet
+func overrideField(t reflect.Type, typeName string, fe orderedEntry) (RuleSet, CompileErrors) {
+	f, ok := t.FieldByName(fe.key)
+	if !ok || len(f.Index) != 1 {
+		return nil, CompileErrors{fmt.Errorf("%s.%s: 字段不存在", typeName, fe.key)}
+	}
+	tag, ok := fe.value.(string)
+	if !ok {
+		return nil, CompileErrors{fmt.Errorf("%s.%s: 规则必须是字符串", typeName, fe.key)}
+	}
+	if tag == skipValidationTag {
+		return RuleSet{}, nil
+	}
+
+	rules, err := parseRules(tag)
+	if err != nil {
+		if e, ok := err.(*TagError); ok {
+			e.Struct, e.Field = typeName, f.Name
+		}
+		return nil, CompileErrors{err}
+	}
+	if len(rules) == 0 {
+		return nil, CompileErrors{fmt.Errorf("%s.%s: 规则不能为空, 删除所有规则请使用 %s", typeName, fe.key, skipValidationTag)}
+	}
+	var errs CompileErrors
+	ft := NewFieldType(f.Type)
+	for _, r := range rules {
+		if err = CheckRule(r, ft); err != nil {
+			errs = append(errs, &RuleError{Struct: typeName, Field: f.Name, Rule: r.Name, Column: r.Column, Msg: err.Error()})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return rules, nil
+}
@@ -0,0 +1,282 @@
+package gvalid
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/25 10:40
+ * @Desc:
+ */
+
+type overrideNote struct {
+	Text string `valid:"required" name:"备注"`
+}
+
+// Valid 验证过程中重新加载覆盖规则
+func (n *overrideNote) Valid(valid *Validation) {
+	if n.Text == "reload" {
+		_ = LoadOverrides([]byte(`{"overrideGoods": {"Summary": "lte=1"}}`))
+	}
+}
+
+type overrideGoods struct {
+	Note    overrideNote `valid:"dive"`
+	Summary string       `valid:"required,lte=10" name:"摘要"`
+	Status  int          `valid:"in=1 2" name:"状态"`
+	Remark  string
+	Code    string `valid:"required,len=3" name:"编码"`
+}
+
+func init() {
+	RegisterOverrideTypes(&overrideGoods{}, overrideNote{})
+	g := &overrideGoods{}
+	MustRules(g, Field(&g.Remark, MaxLen(5)).Label("说明"))
+}
+
+func validGoods(g *overrideGoods) []string {
+	v := &Validation{}
+	_, err := v.Valid(g)
+	So(err, ShouldBeNil)
+	return messages(v.Errors)
+}
+
+func TestParseYAML(t *testing.T) {
+	Convey("parse yaml", t, func() {
+		m, err := parseYAML([]byte(`
+# 覆盖规则
+---
+Goods:
+  Summary: required,lte=200  # 放宽
+  "Quoted Key": 'it''s'
+  Escaped: "a\tb # c" # 注释
+  Regex: regex=(/^#[a-z]+$/)
+  Drop: -
+  Empty:
+  Nested:
+    Deep: in=1 2
+Other: x
+`))
+		So(err, ShouldBeNil)
+		So(m, ShouldResemble, orderedMap{
+			{key: "Goods", value: orderedMap{
+				{key: "Summary", value: "required,lte=200"},
+				{key: "Quoted Key", value: "it's"},
+				{key: "Escaped", value: "a\tb # c"},
+				{key: "Regex", value: "regex=(/^#[a-z]+$/)"},
+				{key: "Drop", value: "-"},
+				{key: "Empty", value: ""},
+				{key: "Nested", value: orderedMap{{key: "Deep", value: "in=1 2"}}},
+			}},
+			{key: "Other", value: "x"},
+		})
+
+		m, err = parseYAML([]byte("  \n# only comments\n"))
+		So(err, ShouldBeNil)
+		So(m, ShouldBeEmpty)
+
+		// 不支持的语法返回错误
+		for data, msg := range map[string]string{
+			"a:\n  b: 1\n c: 2":    "yaml 第 3 行: 缩进错误",
+			"a: 1\n  b: 2":         "yaml 第 2 行: 缩进错误",
+			"a:\n  - 1":            "yaml 第 2 行: 不支持列表",
+			"a: - 1":               "yaml 第 1 行: 不支持列表 - 1",
+			"a: 1\na: 2":           "yaml 第 2 行: 重复的键 a",
+			"a":                    "yaml 第 1 行: a 缺少 :",
+			`"a"b: 1`:              "yaml 第 1 行: 键 a 之后缺少 :",
+			"a: [1, 2]":            "yaml 第 1 行: 不支持的值 [1, 2], 请使用引号",
+			"a: {b: 1}":            "yaml 第 1 行: 不支持的值 {b: 1}, 请使用引号",
+			"a: |\n  x":            "yaml 第 1 行: 不支持的值 |, 请使用引号",
+			"a: &x b":              "yaml 第 1 行: 不支持的值 &x b, 请使用引号",
+			"? a\n: b":             "yaml 第 1 行: 不支持的键 ? a, 请使用引号",
+			"a: b: c":              "yaml 第 1 行: 值 b: c 中包含 :, 请使用引号",
+			"a: 'x":                "yaml 第 1 行: 引号未闭合 'x",
+			`a: "x" y`:             "yaml 第 1 行: 引号之后多余的内容 y",
+			"a:\n\tb: 1":           "yaml 第 2 行: 不能使用 tab 缩进",
+			`a: "\q"`:              `yaml 第 1 行: 无效的字符串 "\q"`,
+			"a:\n  b: 1\n    c: 2": "yaml 第 3 行: 缩进错误",
+			"a: 1\n---\nb: 2":      "yaml 第 2 行: 不支持多个文档",
+			"%YAML 1.2\na: 1":      "yaml 第 1 行: 不支持的语法 %YAML 1.2",
+		} {
+			_, err = parseYAML([]byte(data))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, msg)
+		}
+	})
+}
+
+func TestOverrides(t *testing.T) {
+	defer ResetOverrides()
+	g := &overrideGoods{Note: overrideNote{Text: "x"}, Summary: "abcdefghijkl", Status: 3, Remark: "abcdef", Code: "abcd"}
+
+	Convey("precedence", t, func() {
+		So(validGoods(g), ShouldResemble, []string{
+			"Summary lte 摘要 长度必须是小于等于 10",
+			"Status in 状态 必须是 1 2 其中一个",
+			"Remark lte 说明 长度必须是小于等于 5",
+			"Code len 编码 长度必须是等于 3",
+		})
+
+		// 同名规则以覆盖规则为准, 不同名的规则合并, - 删除所有规则
+		So(LoadOverrides([]byte(`{
+  "overrideGoods": {
+    "Summary": "lte=20",
+    "Status": "in=1 2 3,gte=4",
+    "Remark": "lte=3",
+    "Code": "-"
+  }
+}`)), ShouldBeNil)
+		So(validGoods(g), ShouldResemble, []string{
+			"Status gte 状态 必须是大于等于 4",
+			"Remark lte 说明 长度必须是小于等于 3",
+		})
+		g.Summary = ""
+		So(validGoods(g), ShouldResemble, []string{
+			"Summary required 摘要 不能为空或零值",
+			"Status gte 状态 必须是大于等于 4",
+			"Remark lte 说明 长度必须是小于等于 3",
+		})
+		g.Summary = "abcdefghijkl"
+
+		ts, err := DescribeType(reflect.TypeOf(g))
+		So(err, ShouldBeNil)
+		So(ts.Fields[4].Rules, ShouldBeEmpty)
+
+		ResetOverrides()
+		So(validGoods(g), ShouldHaveLength, 4)
+	})
+
+	Convey("yaml", t, func() {
+		So(LoadOverrides([]byte(`
+# 放宽摘要的长度
+overrideGoods:
+  Summary: lte=20
+  Status: "in=1 2 3,gte=4"
+  Remark: lte=3
+  Code: -
+`)), ShouldBeNil)
+		So(validGoods(g), ShouldResemble, []string{
+			"Status gte 状态 必须是大于等于 4",
+			"Remark lte 说明 长度必须是小于等于 3",
+		})
+		ResetOverrides()
+	})
+
+	Convey("package name", t, func() {
+		So(LoadOverrides([]byte(`{"gvalid.overrideGoods": {"Summary": "lte=5"}, "overrideNote": {"Text": "-"}}`)), ShouldBeNil)
+		g2 := *g
+		g2.Note.Text = ""
+		So(validGoods(&g2)[0], ShouldEqual, "Summary lte 摘要 长度必须是小于等于 5")
+		ResetOverrides()
+	})
+
+	Convey("checked on load", t, func() {
+		So(LoadOverrides([]byte("overrideGoods:\n  Summary: lte=20\n")), ShouldBeNil)
+
+		err := LoadOverrides([]byte(`{
+  "Unknown": {"Name": "required"},
+  "overrideGoods": {
+    "Summary": "lte=x",
+    "Status": "email,in=1 2",
+    "Title": "required",
+    "Code": "in='a",
+    "Remark": ""
+  },
+  "overrideNote": "required"
+}`))
+		var errs CompileErrors
+		So(errors.As(err, &errs), ShouldBeTrue)
+		So(errorStrings(errs), ShouldResemble, []string{
+			"Unknown: 类型未通过 RegisterOverrideTypes 注册",
+			"overrideGoods.Summary: lte 第 1 列: lte 的参数 x 应为整数",
+			"overrideGoods.Status: email 第 1 列: email 不支持 int 类型",
+			"overrideGoods.Title: 字段不存在",
+			`overrideGoods.Code: valid:"in='a" 第 4 列: 引号未闭合`,
+			"overrideGoods.Remark: 规则不能为空, 删除所有规则请使用 -",
+			"overrideNote: 值必须是 字段名 => 规则",
+		})
+		So(LoadOverrides([]byte(`{"overrideGoods": {"Summary": 1}}`)).Error(), ShouldEqual, "overrideGoods.Summary: 规则必须是字符串")
+		So(LoadOverrides([]byte("overrideGoods:\n  Summary: [lte=20]\n")).Error(), ShouldEqual, "yaml 第 2 行: 不支持的值 [lte=20], 请使用引号")
+		So(LoadOverrides([]byte(`{"overrideGoods": {}} {}`)).Error(), ShouldEqual, "json: 覆盖规则必须是单个 JSON 对象")
+		So(LoadOverrides([]byte(`{"overrideGoods": {"Summary": "lte=20"}`)), ShouldNotBeNil)
+
+		// 加载失败时保留之前的覆盖规则
+		So(validGoods(g)[0], ShouldEqual, "Status in 状态 必须是 1 2 其中一个")
+		ResetOverrides()
+	})
+
+	Convey("reload during validation", t, func() {
+		g2 := *g
+		g2.Note.Text = "reload"
+		So(validGoods(&g2)[0], ShouldEqual, "Summary lte 摘要 长度必须是小于等于 10")
+		So(validGoods(&g2)[0], ShouldEqual, "Summary lte 摘要 长度必须是小于等于 1")
+		ResetOverrides()
+	})
+}
+
+func TestWatchOverridesFile(t *testing.T) {
+	defer ResetOverrides()
+	Convey("watch file", t, func() {
+		path := filepath.Join(t.TempDir(), "rules.yaml")
+		So(os.WriteFile(path, []byte("overrideGoods:\n  Summary: lte=20\n"), 0o644), ShouldBeNil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		errs := make(chan error, 10)
+		So(WatchOverridesFile(ctx, path, 5*time.Millisecond, func(err error) { errs <- err }), ShouldBeNil)
+		g := &overrideGoods{Note: overrideNote{Text: "x"}, Summary: "abcdefghijkl", Code: "abc"}
+		So(validGoods(g), ShouldBeEmpty)
+
+		So(os.WriteFile(path, []byte("overrideGoods:\n  Summary: lte=bad\n"), 0o644), ShouldBeNil)
+		select {
+		case err := <-errs:
+			So(err.Error(), ShouldContainSubstring, "lte 的参数 bad 应为整数")
+		case <-time.After(5 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+		So(validGoods(g), ShouldBeEmpty)
+
+		// 加载失败的文件持续重试, 修改后大小及修改时间不变同样重新加载
+		fi, err := os.Stat(path)
+		So(err, ShouldBeNil)
+		So(os.WriteFile(path, []byte("overrideGoods:\n  Summary: lte=002\n"), 0o644), ShouldBeNil)
+		So(os.Chtimes(path, fi.ModTime(), fi.ModTime()), ShouldBeNil)
+		deadline := time.Now().Add(5 * time.Second)
+		for len(validGoods(g)) == 0 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		So(validGoods(g), ShouldResemble, []string{"Summary lte 摘要 长度必须是小于等于 2"})
+		// 相同的错误只报告一次
+		So(errs, ShouldHaveLength, 0)
+	})
+
+	Convey("initial load error", t, func() {
+		ResetOverrides()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		path := filepath.Join(t.TempDir(), "missing.yaml")
+		var calls int32
+		err := WatchOverridesFile(ctx, path, 5*time.Millisecond, func(error) { atomic.AddInt32(&calls, 1) })
+		So(os.IsNotExist(err), ShouldBeTrue)
+
+		So(os.WriteFile(path, []byte("overrideGoods:\n  Summary: lte=3\n"), 0o644), ShouldBeNil)
+		g := &overrideGoods{Note: overrideNote{Text: "x"}, Summary: "abcde", Code: "abc"}
+		deadline := time.Now().Add(5 * time.Second)
+		for len(validGoods(g)) == 0 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		So(validGoods(g), ShouldResemble, []string{"Summary lte 摘要 长度必须是小于等于 3"})
+		So(atomic.LoadInt32(&calls), ShouldEqual, 0)
+	})
+}
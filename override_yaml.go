@@ -0,0 +1,202 @@
+package gvalid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/25 10:20
+ * @Desc: 解析覆盖规则使用的 YAML 子集: 块格式的对象, 值为字符串, 不依赖第三方库
+ */
+
+// yamlLine YAML 的一行, 不含空行及注释行
+type yamlLine struct {
+	no, indent int
+	text       string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML 解析块格式的 YAML 对象, 值为字符串或嵌套的对象, 空值为空字符串
+// 只支持 键: 值, 不加引号, 单引号或双引号的字符串, 及注释
+// 列表, 流格式 ({}, []), 多行字符串, 锚点, 标签及多文档返回错误, 不会被静默忽略
+func parseYAML(data []byte) (orderedMap, error) {
+	p := &yamlParser{}
+	for i, s := range strings.Split(string(data), "\n") {
+		s = strings.TrimRight(s, " \r")
+		text := strings.TrimLeft(s, " ")
+		if text == "" || text[0] == '#' {
+			continue
+		}
+		if text[0] == '\t' {
+			return nil, fmt.Errorf("yaml 第 %d 行: 不能使用 tab 缩进", i+1)
+		}
+		if text == "---" || strings.HasPrefix(text, "--- ") {
+			if len(p.lines) > 0 {
+				return nil, fmt.Errorf("yaml 第 %d 行: 不支持多个文档", i+1)
+			}
+			continue
+		}
+		if text == "..." || text[0] == '%' {
+			return nil, fmt.Errorf("yaml 第 %d 行: 不支持的语法 %s", i+1, text)
+		}
+		p.lines = append(p.lines, yamlLine{no: i + 1, indent: len(s) - len(text), text: text})
+	}
+	if len(p.lines) == 0 {
+		return orderedMap{}, nil
+	}
+	m, err := p.mapping(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("yaml 第 %d 行: 缩进错误", p.lines[p.pos].no)
+	}
+	return m, nil
+}
+
+// mapping 解析缩进为 indent 的对象
+func (p *yamlParser) mapping(indent int) (orderedMap, error) {
+	m := orderedMap{}
+	keys := make(map[string]bool)
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("yaml 第 %d 行: 缩进错误", l.no)
+		}
+		if l.text == "-" || strings.HasPrefix(l.text, "- ") {
+			return nil, fmt.Errorf("yaml 第 %d 行: 不支持列表", l.no)
+		}
+		key, rest, err := yamlSplitKey(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("yaml 第 %d 行: %v", l.no, err)
+		}
+		if keys[key] {
+			return nil, fmt.Errorf("yaml 第 %d 行: 重复的键 %s", l.no, key)
+		}
+		keys[key] = true
+		p.pos++
+
+		if rest != "" {
+			v, err := yamlParseScalar(rest)
+			if err != nil {
+				return nil, fmt.Errorf("yaml 第 %d 行: %v", l.no, err)
+			}
+			m = append(m, orderedEntry{key: key, value: v})
+			continue
+		}
+		if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+			child, err := p.mapping(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, orderedEntry{key: key, value: child})
+			continue
+		}
+		m = append(m, orderedEntry{key: key, value: ""})
+	}
+	return m, nil
+}
+
+// yamlSplitKey 拆分 key: value, 去除 value 之后的注释
+func yamlSplitKey(text string) (key, rest string, err error) {
+	switch text[0] {
+	case '"', '\'':
+		var n int
+		if key, n, err = yamlQuoted(text); err != nil {
+			return
+		}
+		text = text[n:]
+		if !strings.HasPrefix(text, ":") {
+			return "", "", fmt.Errorf("键 %s 之后缺少 :", key)
+		}
+		rest = text[1:]
+	case '?', '[', '{', '&', '*', '!', '|', '>', '@', '`':
+		return "", "", fmt.Errorf("不支持的键 %s, 请使用引号", text)
+	default:
+		i := strings.Index(text+" ", ": ")
+		if i < 0 {
+			return "", "", fmt.Errorf("%s 缺少 :", text)
+		}
+		key, rest = strings.TrimSpace(text[:i]), text[i+1:]
+	}
+	if rest != "" && rest[0] != ' ' {
+		return "", "", fmt.Errorf("键 %s 的 : 之后缺少空格", key)
+	}
+	if rest = strings.TrimSpace(rest); strings.HasPrefix(rest, "#") {
+		rest = ""
+	}
+	return key, rest, nil
+}
+
+// yamlParseScalar 解析字符串值, 支持单引号, 双引号及不加引号的值
+// 不加引号的值中 " #" 之后为注释, 包含 ": " 时有歧义, 须使用引号
+func yamlParseScalar(s string) (string, error) {
+	switch s[0] {
+	case '"', '\'':
+		v, n, err := yamlQuoted(s)
+		if err != nil {
+			return "", err
+		}
+		if tail := strings.TrimSpace(s[n:]); tail != "" && tail[0] != '#' {
+			return "", fmt.Errorf("引号之后多余的内容 %s", tail)
+		}
+		return v, nil
+	case '[', '{', '|', '>', '&', '*', '!', '%', '@', '`':
+		return "", fmt.Errorf("不支持的值 %s, 请使用引号", s)
+	}
+	if s != "-" && strings.HasPrefix(s, "- ") {
+		return "", fmt.Errorf("不支持列表 %s", s)
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+	if strings.Contains(s+" ", ": ") {
+		return "", fmt.Errorf("值 %s 中包含 :, 请使用引号", s)
+	}
+	return s, nil
+}
+
+// yamlQuoted 解析开头的引号字符串, 返回值及其长度
+func yamlQuoted(s string) (string, int, error) {
+	if s[0] == '\'' {
+		var b strings.Builder
+		for i := 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				b.WriteByte(s[i])
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return b.String(), i + 1, nil
+		}
+		return "", 0, fmt.Errorf("引号未闭合 %s", s)
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			v, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", 0, fmt.Errorf("无效的字符串 %s", s[:i+1])
+			}
+			return v, i + 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("引号未闭合 %s", s)
+}
@@ -0,0 +1,150 @@
+package gvalid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/19 15:40
+ * @Desc: 部分验证, 用于 PATCH 等只更新部分字段的场景
+ */
+
+// StructPartial 只验证 fields 中的字段及其下级字段
+// fields 为字段路径, 如 Name, Gallery.ImgUrl, Address.City 匹配 Address 中所有元素的 City, Address[0].City 只匹配第一个
+func (valid *Validation) StructPartial(obj interface{}, fields ...string) (b bool, err error) {
+	return valid.validPartial(obj, func(path string) (self, descend bool) {
+		for _, field := range fields {
+			if pathHasPrefix(path, field) || pathHasPrefix(trimIndex(path), field) {
+				return true, true
+			}
+			if pathHasPrefix(field, path) || pathHasPrefix(field, trimIndex(path)) {
+				descend = true
+			}
+		}
+		return
+	})
+}
+
+// StructExcept 不验证 fields 中的字段及其下级字段, fields 格式同 StructPartial
+func (valid *Validation) StructExcept(obj interface{}, fields ...string) (b bool, err error) {
+	return valid.validPartial(obj, func(path string) (self, descend bool) {
+		for _, field := range fields {
+			if pathHasPrefix(path, field) || pathHasPrefix(trimIndex(path), field) {
+				return false, false
+			}
+		}
+		return true, true
+	})
+}
+
+// StructPartialJSON 只验证 keys 中出现的字段
+// keys 为 json 字段名路径, 如 name, gallery.imgUrl, list[0].title, 可通过 JSONKeys 从请求体获取
+// 与 StructPartial 不同, 出现的嵌套对象只验证其自身规则, 其下级字段同样需要出现在 keys 中
+func (valid *Validation) StructPartialJSON(obj interface{}, keys ...string) (b bool, err error) {
+	tOf := reflect.TypeOf(obj)
+	if tOf == nil || !isStructOrStructPtr(tOf) {
+		err = fmt.Errorf("%v 必须是 结构体 或者 结构体指针", obj)
+		return
+	}
+
+	present := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		present[jsonKeyPath(tOf, key)] = struct{}{}
+	}
+
+	return valid.validPartial(obj, func(path string) (self, descend bool) {
+		if _, ok := present[path]; ok {
+			return true, true
+		}
+		for p := range present {
+			if pathHasPrefix(p, path) {
+				return false, true
+			}
+		}
+		return
+	})
+}
+
+// JSONKeys 获取 JSON 对象中出现的字段路径, 如 {"gallery":{"imgUrl":""},"list":[{"title":""}]}
+// 返回 gallery, gallery.imgUrl, list, list[0], list[0].title
+func JSONKeys(data []byte) (keys []string, err error) {
+	var raw interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&raw); err != nil {
+		return
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		err = fmt.Errorf("%s 必须是 JSON 对象", data)
+		return
+	}
+	collectJSONKeys(obj, "", &keys)
+	return
+}
+
+func collectJSONKeys(raw interface{}, path string, keys *[]string) {
+	switch val := raw.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(val) {
+			p := joinPath(path, key)
+			*keys = append(*keys, p)
+			collectJSONKeys(val[key], p, keys)
+		}
+	case []interface{}:
+		for i, elem := range val {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			*keys = append(*keys, p)
+			collectJSONKeys(elem, p, keys)
+		}
+	}
+}
+
+// jsonKeyPath json 字段名路径转换为字段路径, 如 list[0].title => List[0].Title, 找不到的字段保持原样
+func jsonKeyPath(t reflect.Type, key string) (path string) {
+	for _, seg := range strings.Split(key, ".") {
+		name, index := seg, ""
+		if i := strings.IndexByte(seg, '['); i != -1 {
+			name, index = seg[:i], seg[i:]
+		}
+		for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			t = t.Elem()
+		}
+		if t != nil && t.Kind() == reflect.Struct {
+			if f, goPath, found := jsonField(t, name); found {
+				name, t = goPath, f.Type
+			} else {
+				t = nil
+			}
+		}
+		path = joinPath(path, name) + index
+	}
+	return
+}
+
+// validPartial 按 partial 验证 obj
+func (valid *Validation) validPartial(obj interface{}, partial func(path string) (self, descend bool)) (b bool, err error) {
+	prefix := valid.path
+	valid.partial = func(path string) (self, descend bool) {
+		// 部分验证的字段路径相对于 obj
+		if prefix != "" {
+			if !pathHasPrefix(path, prefix) {
+				return true, true
+			}
+			path = strings.TrimPrefix(strings.TrimPrefix(path, prefix), ".")
+		}
+		if path == "" {
+			return false, true
+		}
+		return partial(path)
+	}
+	defer func() { valid.partial = nil }()
+
+	return valid.Valid(obj)
+}
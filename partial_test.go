@@ -0,0 +1,92 @@
+package gvalid
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/19 16:25
+ * @Desc:
+ */
+
+func errorPaths(v *Validation) []string {
+	paths := make([]string, 0, len(v.Errors))
+	for _, e := range v.Errors {
+		paths = append(paths, e.Path)
+	}
+	return paths
+}
+
+func TestStructPartial(t *testing.T) {
+	Convey("test struct partial", t, func() {
+		u := &jsonGoods{jsonSpu: jsonSpu{Name: "衣服123456789", Gallery: &jsonImg{}, List: []*jsonImg{{}, {}}}}
+
+		v := &Validation{}
+		b, err := v.StructPartial(u, "Name", "Gallery.ImgUrl")
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(errorPaths(v), ShouldResemble, []string{"Name", "Gallery.ImgUrl"})
+
+		v = &Validation{}
+		_, _ = v.StructPartial(u, "List.ImgUrl")
+		So(errorPaths(v), ShouldResemble, []string{"List[0].ImgUrl", "List[1].ImgUrl"})
+
+		v = &Validation{}
+		_, _ = v.StructPartial(u, "List[1]")
+		So(errorPaths(v), ShouldResemble, []string{"List[1].ImgUrl"})
+
+		v = &Validation{}
+		_, _ = v.StructPartial(u, "Cate")
+		So(errorPaths(v), ShouldResemble, []string{"Cate"})
+	})
+
+	Convey("test custom valid", t, func() {
+		u := &Account{Username: "user123", Password: "1234567a", RePassword: "1"}
+
+		v := &Validation{}
+		b, err := v.StructPartial(u, "Username", "Password")
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+	})
+}
+
+func TestStructExcept(t *testing.T) {
+	Convey("test struct except", t, func() {
+		u := &jsonGoods{jsonSpu: jsonSpu{Cate: 1, Name: "衣服", Gallery: &jsonImg{}, List: []*jsonImg{{}}}}
+
+		v := &Validation{}
+		b, err := v.StructExcept(u, "Gallery.ImgUrl", "Stock")
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(errorPaths(v), ShouldResemble, []string{"List[0].ImgUrl"})
+
+		v = &Validation{}
+		b, _ = v.StructExcept(u, "Gallery", "List", "Stock")
+		So(b, ShouldBeTrue)
+	})
+}
+
+func TestStructPartialJSON(t *testing.T) {
+	Convey("test struct partial json", t, func() {
+		data := []byte(`{"name":"衣服123456789","gallery":{"imgUrl":""},"list":[{"imgUrl":"a.png"},{}]}`)
+		keys, err := JSONKeys(data)
+		So(err, ShouldBeNil)
+		So(keys, ShouldResemble, []string{"gallery", "gallery.imgUrl", "list", "list[0]", "list[0].imgUrl", "list[1]", "name"})
+
+		u := &jsonGoods{}
+		v := &Validation{}
+		b, err := v.ValidateJSON(data, u)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+
+		v = &Validation{}
+		b, err = v.StructPartialJSON(u, keys...)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(errorPaths(v), ShouldResemble, []string{"Name", "Gallery.ImgUrl"})
+	})
+}
@@ -0,0 +1,1029 @@
+package gvalid
+
+import (
+	"encoding/base64"
+	"fmt"
+	"go/token"
+	"math"
+	"math/rand"
+	"reflect"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/24 10:00
+ * @Desc: 根据规则生成测试数据, 通过验证的数据及只违反一条规则的数据
+ */
+
+// Sample 根据规则生成测试数据, 相同的 Seed 生成相同的数据
+// 只生成有规则的字段, 没有规则及未导出的字段保持原值; 不考虑 ValidCustom 及 RegisterStructValidation
+type Sample struct {
+	Seed int64
+	// Groups 验证场景, 同 Validation.Groups
+	Groups []string
+}
+
+// InvalidSample 只违反一条规则的测试数据
+type InvalidSample struct {
+	// Value 与 obj 类型相同的结构体指针
+	Value interface{}
+	// Path, Rule 预期的 Error.Path 及 Error.Rule, slice 中的结构体为第一个元素, 如 Items[0].Sku
+	Path string
+	Rule string
+}
+
+// sampleTries 每个值最多尝试生成的次数
+const sampleTries = 64
+
+// sampleRules 可以生成违反数据的规则, trimSpace, default 不会验证失败, immutable, transitions 仅 ValidateUpdate 时验证
+var sampleRules = map[string]bool{
+	"required": true, "empty": true, "gt": true, "gte": true, "lt": true, "lte": true, "len": true,
+	"in": true, "sin": true, "enum": true, "distinct": true, "regex": true, "date": true, "numeric": true,
+	"email": true, "mobile": true, "base64": true, "ip": true, "url": true, "idCard": true, "strGte": true, "strLte": true,
+}
+
+// sampleSkips 生成时不检查的规则
+var sampleSkips = map[string]bool{
+	"dive": true, "default": true, "trimSpace": true, SensitiveTag: true, "immutable": true, "transitions": true,
+}
+
+// Valid 按规则填充 obj, obj 为结构体指针, 生成的数据未通过规则验证时返回错误
+func (s *Sample) Valid(obj interface{}) error {
+	vOf := reflect.ValueOf(obj)
+	if obj == nil || !isStructPtr(vOf.Type()) || vOf.IsNil() {
+		return fmt.Errorf("%v 必须是 结构体指针", obj)
+	}
+	if err := s.sampler(s.Seed).fill(vOf.Elem()); err != nil {
+		return err
+	}
+
+	// 验证会修改数据, 如 trimSpace, 使用另一份相同的数据
+	check := reflect.New(vOf.Type().Elem())
+	check.Elem().Set(vOf.Elem())
+	if err := s.sampler(s.Seed).fill(check.Elem()); err != nil {
+		return err
+	}
+	valid := &Validation{Groups: s.Groups}
+	if _, err := valid.Valid(check.Interface()); err != nil {
+		return err
+	}
+	// 未导出的字段不生成
+	var failed []string
+	for _, e := range valid.Errors {
+		if e.Rule != CustomRule && token.IsExported(e.Field) {
+			failed = append(failed, e.Path+": "+e.Rule)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s 生成的数据未通过验证: %s", vOf.Type().Elem().Name(), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Invalid 为 obj 的类型的每个字段的每条规则生成只违反该规则的数据, obj 为结构体或结构体指针
+// dive 的结构体同样生成, 无法生成的规则省略, 如 trimSpace, 与其它规则冲突的规则
+func (s *Sample) Invalid(obj interface{}) ([]*InvalidSample, error) {
+	t := reflect.TypeOf(obj)
+	if t == nil || !isStructOrStructPtr(t) {
+		return nil, fmt.Errorf("%v 必须是 结构体 或者 结构体指针", obj)
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// build 生成通过验证的数据, 修改 target 的字段为违反其规则的值
+	build := func(i int, target *sampleTarget) (reflect.Value, bool, error) {
+		root := reflect.New(t)
+		if err := s.sampler(s.Seed).fill(root.Elem()); err != nil {
+			return root, false, err
+		}
+		if target == nil {
+			return root, true, nil
+		}
+		f := root.Elem()
+		for _, step := range target.steps {
+			f = indirect(f)
+			if step == -1 {
+				f = f.Index(0)
+			} else {
+				f = f.Field(step)
+			}
+		}
+		return root, s.sampler(s.Seed+int64(i)+1).violate(f, target.field, target.rules, target.rule), nil
+	}
+
+	root, _, err := build(0, nil)
+	if err != nil {
+		return nil, err
+	}
+	g := s.sampler(s.Seed)
+	targets, err := g.targets(root.Elem(), "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []*InvalidSample
+	for i, target := range targets {
+		v, ok, err := build(i, target)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		// 验证会修改数据, 使用另一份相同的数据
+		check, _, _ := build(i, target)
+		valid := &Validation{Groups: s.Groups}
+		if _, err = valid.Valid(check.Interface()); err != nil {
+			return nil, err
+		}
+		for _, e := range valid.Errors {
+			if e.Path == target.path && e.Rule == target.rule.Name {
+				samples = append(samples, &InvalidSample{Value: v.Interface(), Path: target.path, Rule: target.rule.Name})
+				break
+			}
+		}
+	}
+	return samples, nil
+}
+
+func (s *Sample) sampler(seed int64) *sampler {
+	return &sampler{rand: rand.New(rand.NewSource(seed)), groups: s.Groups, filling: make(map[reflect.Type]bool)}
+}
+
+// sampleTarget 要违反的规则, steps 为从根结构体到字段的下标, -1 为 slice 的第一个元素
+type sampleTarget struct {
+	steps []int
+	path  string
+	field reflect.StructField
+	rules RuleSet
+	rule  Rule
+}
+
+type sampler struct {
+	rand   *rand.Rand
+	groups []string
+	// filling 正在生成的结构体, 递归的类型不再生成
+	filling map[reflect.Type]bool
+}
+
+// rules 字段在验证场景下执行的规则, 未导出及不能设置的字段为空
+// 未导出的匿名结构体不能设置, 但其导出的字段可以设置
+func (g *sampler) rules(v reflect.Value, i int) (RuleSet, error) {
+	sf := v.Type().Field(i)
+	if (sf.PkgPath != "" && !sf.Anonymous) || (!v.Field(i).CanSet() && sf.Type.Kind() != reflect.Struct) {
+		return nil, nil
+	}
+	rules, err := fieldRules(v.Type(), sf)
+	if err != nil {
+		return nil, err
+	}
+	return rules.ForGroups(g.groups...), nil
+}
+
+// fill 填充结构体 v 中有规则的字段
+func (g *sampler) fill(v reflect.Value) error {
+	g.filling[v.Type()] = true
+	defer delete(g.filling, v.Type())
+	for i := 0; i < v.NumField(); i++ {
+		rules, err := g.rules(v, i)
+		if err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		if !v.Field(i).CanSet() {
+			if rules.Has(ruleName(diveFunc)) {
+				if err = g.fill(v.Field(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		val, err := g.valid(v.Type().Field(i), rules)
+		if err != nil {
+			return err
+		}
+		v.Field(i).Set(val)
+	}
+	return nil
+}
+
+// targets v 中的字段及其 dive 的结构体的规则
+func (g *sampler) targets(v reflect.Value, path string, steps []int) (targets []*sampleTarget, err error) {
+	for i := 0; i < v.NumField(); i++ {
+		var rules RuleSet
+		if rules, err = g.rules(v, i); err != nil {
+			return
+		}
+		sf := v.Type().Field(i)
+		fpath := fieldPath(path, sf)
+		fsteps := append(append([]int{}, steps...), i)
+		for _, r := range rules {
+			if sampleRules[r.Name] && v.Field(i).CanSet() {
+				targets = append(targets, &sampleTarget{steps: fsteps, path: fpath, field: sf, rules: rules, rule: r})
+			}
+		}
+		if !rules.Has(ruleName(diveFunc)) {
+			continue
+		}
+		var sub []*sampleTarget
+		f := indirect(v.Field(i))
+		switch {
+		case f.Kind() == reflect.Struct && !isCustomType(f.Type()):
+			sub, err = g.targets(f, fpath, fsteps)
+		case f.Kind() == reflect.Slice && f.Len() > 0 && indirect(f.Index(0)).Kind() == reflect.Struct:
+			sub, err = g.targets(indirect(f.Index(0)), fpath+"[0]", append(fsteps, -1))
+		}
+		if err != nil {
+			return
+		}
+		targets = append(targets, sub...)
+	}
+	return
+}
+
+// check 字段的值为 v 时规则验证的错误, 不包括 dive
+func (g *sampler) check(sf reflect.StructField, v reflect.Value, rules RuleSet) []*Error {
+	valid := &Validation{}
+	ft, fv := sf, v
+	if cv, ok := customValue(v); ok {
+		ft.Type, fv = cv.Type(), cv
+	}
+	for _, r := range rules {
+		if sampleSkips[r.Name] {
+			continue
+		}
+		valid.rule, valid.param, valid.values, valid.value = r.Name, r.Param(), r.Values(), fv
+		if _, err := validFuncMap.Call(validFuncPrefix+toUpperCamel(r.Name), valid, ft, fv, r.funcParam()); err != nil {
+			valid.Errors = append(valid.Errors, &Error{Field: sf.Name, Rule: r.Name, Message: err.Error()})
+		}
+	}
+	return valid.Errors
+}
+
+// valid 通过 rules 的值, 多次尝试仍无法生成时, 非必填的字段为零值
+func (g *sampler) valid(sf reflect.StructField, rules RuleSet) (reflect.Value, error) {
+	if rules.Has("empty") {
+		return reflect.Zero(sf.Type), nil
+	}
+	for i := 0; i < sampleTries; i++ {
+		v, err := g.candidate(sf.Type, rules, -1)
+		if err != nil {
+			return v, err
+		}
+		if len(g.check(sf, v, rules)) == 0 {
+			return v, nil
+		}
+	}
+	if !rules.Has("required") {
+		return reflect.Zero(sf.Type), nil
+	}
+	return reflect.Value{}, fmt.Errorf("%s.%s: 无法生成通过 %s 的值", sf.Type.Name(), sf.Name, sf.Tag.Get(defaultTagName))
+}
+
+// violate 设置 f 为只违反 rule 的值, 无法只违反 rule 时使用错误最少的值
+func (g *sampler) violate(f reflect.Value, sf reflect.StructField, rules RuleSet, rule Rule) bool {
+	var best reflect.Value
+	fewest := 0
+	for i := 0; i < sampleTries; i++ {
+		v, ok := g.invalid(sf.Type, f, rules, rule, i)
+		if !ok {
+			continue
+		}
+		errs := g.check(sf, v, rules)
+		violated := false
+		for _, e := range errs {
+			violated = violated || e.Rule == rule.Name
+		}
+		if !violated {
+			continue
+		}
+		if len(errs) == 1 {
+			f.Set(v)
+			return true
+		}
+		if !best.IsValid() || len(errs) < fewest {
+			best, fewest = v, len(errs)
+		}
+	}
+	if best.IsValid() {
+		f.Set(best)
+	}
+	return best.IsValid()
+}
+
+// isCustomType 自定义类型, 验证其实际值
+func isCustomType(t reflect.Type) bool {
+	_, ok := customTypeFuncs[t]
+	return ok || t.Implements(valuerType) || reflect.PtrTo(t).Implements(valuerType)
+}
+
+// sampleElem 生成的值的类型, 指针为其指向的类型, database/sql 的 Null* 类型为其值的类型
+func sampleElem(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if isNullType(t) {
+		return t.Field(0).Type
+	}
+	return t
+}
+
+// isNullType 同 database/sql 的 Null* 类型, 第一个字段为值, Valid 表示非 NULL
+func isNullType(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || !isCustomType(t) {
+		return false
+	}
+	f, ok := t.FieldByName("Valid")
+	return ok && f.Type.Kind() == reflect.Bool && t.NumField() == 2 && f.Index[0] == 1
+}
+
+// wrap 将 sampleElem 类型的值 e 转换为 t
+func wrap(t reflect.Type, e reflect.Value) reflect.Value {
+	switch {
+	case t.Kind() == reflect.Ptr:
+		p := reflect.New(t.Elem())
+		p.Elem().Set(wrap(t.Elem(), e))
+		return p
+	case isNullType(t):
+		v := reflect.New(t).Elem()
+		v.Field(0).Set(e)
+		v.Field(1).SetBool(true)
+		return v
+	}
+	return e
+}
+
+// candidate 按规则生成 t 的值, length >= 0 时为字符串, slice, map 的长度
+func (g *sampler) candidate(t reflect.Type, rules RuleSet, length int) (reflect.Value, error) {
+	et := sampleElem(t)
+	if et.Kind() == reflect.Struct && g.filling[et] {
+		return reflect.Zero(t), nil
+	}
+	if isCustomType(et) {
+		// 只支持 database/sql 的 Null* 类型
+		return reflect.Zero(t), nil
+	}
+	v := reflect.New(et).Elem()
+	switch {
+	case et.Kind() == reflect.String:
+		v.SetString(g.string(et, rules, length))
+	case isIntKind(et.Kind()):
+		n := g.int(et, rules)
+		if v.OverflowInt(n) {
+			return reflect.Zero(t), nil
+		}
+		v.SetInt(n)
+	case isUintKind(et.Kind()):
+		n := g.int(et, rules)
+		if n < 0 || v.OverflowUint(uint64(n)) {
+			return reflect.Zero(t), nil
+		}
+		v.SetUint(uint64(n))
+	case isFloatKind(et.Kind()):
+		v.SetFloat(g.float(rules))
+	case et.Kind() == reflect.Bool:
+		v.SetBool(true)
+	case et.Kind() == reflect.Slice || et.Kind() == reflect.Array:
+		if err := g.slice(v, rules, length); err != nil {
+			return v, err
+		}
+	case et.Kind() == reflect.Map:
+		g.mapValue(v, rules, length)
+	case et == timeType:
+		v.Set(reflect.ValueOf(g.time()))
+	case et.Kind() == reflect.Struct:
+		if !rules.Has(ruleName(diveFunc)) && !rules.Has("required") {
+			return reflect.Zero(t), nil
+		}
+		if err := g.fill(v); err != nil {
+			return v, err
+		}
+	default:
+		return reflect.Zero(t), nil
+	}
+	return wrap(t, v), nil
+}
+
+// lengthBounds 长度范围, 没有下限时为 min, 没有上限时为下限加 span
+func lengthBounds(rules RuleSet, min, span int) (lo, hi int) {
+	lo, hi = -1, -1
+	for _, r := range rules {
+		n, err := strconv.Atoi(r.Param())
+		if err != nil {
+			continue
+		}
+		switch r.Name {
+		case "gt":
+			lo = n + 1
+		case "gte":
+			lo = n
+		case "lt":
+			hi = n - 1
+		case "lte":
+			hi = n
+		case "len":
+			lo, hi = n, n
+		}
+	}
+	if lo < min {
+		lo = min
+	}
+	if hi < 0 {
+		hi = lo + span
+	}
+	return
+}
+
+// length 范围内的随机长度
+func (g *sampler) length(rules RuleSet, min, span, length int) int {
+	if length >= 0 {
+		return length
+	}
+	lo, hi := lengthBounds(rules, min, span)
+	if hi <= lo {
+		return lo
+	}
+	return lo + g.rand.Intn(hi-lo+1)
+}
+
+// enumValues enum 规则注册的值, enum 没有参数时为类型名注册的值
+func enumValues(t reflect.Type, rules RuleSet) ([]interface{}, bool) {
+	r, ok := rules.Get("enum")
+	if !ok {
+		return nil, false
+	}
+	name := r.Param()
+	if name == "" {
+		name = t.Name()
+	}
+	return EnumValues(name)
+}
+
+const (
+	sampleLetters = "abcdefghijklmnopqrstuvwxyz"
+	sampleDigits  = "0123456789"
+)
+
+func (g *sampler) chars(set string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = set[g.rand.Intn(len(set))]
+	}
+	return string(b)
+}
+
+func (g *sampler) pick(values []string) string {
+	return values[g.rand.Intn(len(values))]
+}
+
+// string 按规则生成字符串, in, enum 及格式规则优先, 否则为指定长度的小写字母
+func (g *sampler) string(t reflect.Type, rules RuleSet, length int) string {
+	n := g.length(rules, 1, 8, length)
+	if r, ok := rules.Get("in"); ok && len(r.Params) > 0 {
+		return g.pick(r.Values())
+	}
+	if values, ok := enumValues(t, rules); ok && len(values) > 0 {
+		return fmt.Sprint(values[g.rand.Intn(len(values))])
+	}
+	if r, ok := rules.Get(toLowerCamel(RegexFunc)); ok {
+		return g.regex(r.Param())
+	}
+	if r, ok := rules.Get("date"); ok {
+		return g.time().Format(r.Param())
+	}
+	// strGte, strLte 的参数本身通过验证, 随机的字母可能不在范围内
+	for _, name := range []string{"strGte", "strLte"} {
+		if r, ok := rules.Get(name); ok && g.rand.Intn(2) == 0 {
+			return r.Param()
+		}
+	}
+	switch {
+	case rules.Has("email"):
+		return g.chars(sampleLetters, atLeast(n-len("@example.com"), 1)) + "@example.com"
+	case rules.Has("mobile"):
+		return "1" + g.chars("3456789", 1) + g.chars(sampleDigits, 9)
+	case rules.Has("idCard"):
+		return g.idCard()
+	case rules.Has("url"):
+		return "https://www." + g.chars(sampleLetters, atLeast(n-len("https://www..com"), 1)) + ".com"
+	case rules.Has("ip"):
+		return fmt.Sprintf("%d.%d.%d.%d", 1+g.rand.Intn(223), g.rand.Intn(256), g.rand.Intn(256), 1+g.rand.Intn(254))
+	case rules.Has("base64"):
+		b := make([]byte, atLeast(n/4*3, 3))
+		g.rand.Read(b)
+		return base64.StdEncoding.EncodeToString(b)
+	case rules.Has("numeric"):
+		return g.chars(sampleDigits, n)
+	}
+	return g.chars(sampleLetters, n)
+}
+
+// idCard 校验码正确的身份证号码
+func (g *sampler) idCard() string {
+	birth := time.Date(1960+g.rand.Intn(45), time.Month(1+g.rand.Intn(12)), 1+g.rand.Intn(28), 0, 0, 0, 0, time.UTC)
+	code := "110105" + birth.Format("20060102") + g.chars(sampleDigits, 3)
+	weight := []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		sum += int(code[i]-'0') * weight[i]
+	}
+	return code + string("10X98765432"[sum%11])
+}
+
+// time 2000 年至 2030 年之间的时间, 精确到秒
+func (g *sampler) time() time.Time {
+	return time.Date(2000+g.rand.Intn(30), time.Month(1+g.rand.Intn(12)), 1+g.rand.Intn(28),
+		g.rand.Intn(24), g.rand.Intn(60), g.rand.Intn(60), 0, loc)
+}
+
+// regex 生成匹配正则的字符串, * + 最多重复 3 次
+func (g *sampler) regex(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	g.regexp(&b, re.Simplify())
+	return b.String()
+}
+
+func (g *sampler) regexp(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			b.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		b.WriteRune(g.class(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteString(g.chars(sampleLetters, 1))
+	case syntax.OpCapture:
+		g.regexp(b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.regexp(b, sub)
+		}
+	case syntax.OpAlternate:
+		g.regexp(b, re.Sub[g.rand.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			min, max = 0, 3
+		case syntax.OpPlus:
+			min, max = 1, 3
+		case syntax.OpQuest:
+			min, max = 0, 1
+		}
+		if max < min {
+			max = min + 3
+		}
+		for n := min + g.rand.Intn(max-min+1); n > 0; n-- {
+			g.regexp(b, re.Sub[0])
+		}
+	}
+}
+
+// class 字符类中的随机字符, 优先使用可见的 ASCII 字符
+func (g *sampler) class(ranges []rune) rune {
+	if len(ranges) == 0 {
+		return 'a'
+	}
+	var visible []rune
+	total := 0
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo < '!' {
+			lo = '!'
+		}
+		if hi > '~' {
+			hi = '~'
+		}
+		if lo <= hi {
+			visible = append(visible, lo, hi)
+			total += int(hi - lo + 1)
+		}
+	}
+	if total == 0 {
+		return ranges[0]
+	}
+	n := g.rand.Intn(total)
+	for i := 0; i < len(visible); i += 2 {
+		if size := int(visible[i+1] - visible[i] + 1); n >= size {
+			n -= size
+		} else {
+			return visible[i] + rune(n)
+		}
+	}
+	return visible[0]
+}
+
+// intBounds gt, gte, lt, lte 的整数范围, 没有下限时为 1, 没有上限时为下限加 99
+func intBounds(rules RuleSet) (lo, hi int64) {
+	hasLo, hasHi := false, false
+	for _, r := range rules {
+		n, err := strconv.ParseInt(r.Param(), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch r.Name {
+		case "gt":
+			lo, hasLo = n+1, true
+		case "gte":
+			lo, hasLo = n, true
+		case "lt":
+			hi, hasHi = n-1, true
+		case "lte":
+			hi, hasHi = n, true
+		}
+	}
+	switch {
+	case !hasLo && !hasHi:
+		lo, hi = 1, 100
+	case !hasLo:
+		if lo = hi - 99; hi >= 1 {
+			lo = 1
+		}
+	case !hasHi:
+		hi = lo + 99
+	}
+	return
+}
+
+// int 按规则生成整数, in, enum 优先, 实现 Enum 的类型为 1 ~ 16 之间的值, 由 check 筛选
+func (g *sampler) int(t reflect.Type, rules RuleSet) int64 {
+	if r, ok := rules.Get("in"); ok && len(r.Params) > 0 {
+		n, _ := strconv.ParseInt(g.pick(r.Values()), 10, 64)
+		return n
+	}
+	if values, ok := enumValues(t, rules); ok && len(values) > 0 {
+		n, _ := strconv.ParseInt(fmt.Sprint(values[g.rand.Intn(len(values))]), 10, 64)
+		return n
+	}
+	if rules.Has("enum") {
+		return 1 + g.rand.Int63n(16)
+	}
+	lo, hi := intBounds(rules)
+	n := lo
+	if hi > lo {
+		n = lo + g.rand.Int63n(hi-lo+1)
+	}
+	if n == 0 {
+		// 零值不验证, 必填时不通过
+		if n = 1; n > hi {
+			n = -1
+		}
+	}
+	return n
+}
+
+// float 按规则生成两位小数, 边界由 check 筛选
+func (g *sampler) float(rules RuleSet) float64 {
+	lo, hi := math.NaN(), math.NaN()
+	for _, r := range rules {
+		f, err := strconv.ParseFloat(r.Param(), 64)
+		if err != nil {
+			continue
+		}
+		switch r.Name {
+		case "gt", "gte":
+			lo = f
+		case "lt", "lte":
+			hi = f
+		}
+	}
+	switch {
+	case math.IsNaN(lo) && math.IsNaN(hi):
+		lo, hi = 1, 100
+	case math.IsNaN(lo):
+		if lo = 0; hi <= 0 {
+			lo = hi - 100
+		}
+	case math.IsNaN(hi):
+		hi = lo + 100
+	}
+	f := math.Round((lo+g.rand.Float64()*(hi-lo))*100) / 100
+	if f == 0 {
+		f = hi
+	}
+	return f
+}
+
+// slice 按规则生成 slice 或数组, sin, enum 的值优先, dive 的结构体按其规则生成, distinct 时元素不重复
+func (g *sampler) slice(v reflect.Value, rules RuleSet, length int) error {
+	et := v.Type().Elem()
+	n := v.Len()
+	var values []string
+	if r, ok := rules.Get("sin"); ok {
+		values = r.Values()
+	} else if enums, ok := enumValues(et, rules); ok {
+		for _, e := range enums {
+			values = append(values, fmt.Sprint(e))
+		}
+	}
+	if v.Kind() == reflect.Slice {
+		if n = g.length(rules, 1, 2, length); rules.Has("distinct") && len(values) > 0 && n > len(values) {
+			n = len(values)
+		}
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+	}
+
+	elemRules := RuleSet{{Name: "required"}}
+	if rules.Has(ruleName(diveFunc)) {
+		elemRules = append(elemRules, Rule{Name: ruleName(diveFunc)})
+	}
+	if r, ok := rules.Get("enum"); ok {
+		elemRules = append(elemRules, r)
+	}
+	used := make(map[string]bool)
+	for i := 0; i < n; i++ {
+		e := v.Index(i)
+		for try := 0; try < sampleTries; try++ {
+			if len(values) > 0 {
+				setString(e, g.pick(values))
+			} else {
+				c, err := g.candidate(et, elemRules, -1)
+				if err != nil {
+					return err
+				}
+				e.Set(c)
+			}
+			if !rules.Has("distinct") || !e.CanInterface() {
+				break
+			}
+			if key := fmt.Sprint(e.Interface()); !used[key] {
+				used[key] = true
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// setString 设置字符串或整数 e 的值为 s
+func setString(e reflect.Value, s string) {
+	e = indirect(e)
+	switch {
+	case e.Kind() == reflect.String:
+		e.SetString(s)
+	case isIntKind(e.Kind()):
+		n, _ := strconv.ParseInt(s, 10, 64)
+		e.SetInt(n)
+	case isUintKind(e.Kind()):
+		n, _ := strconv.ParseUint(s, 10, 64)
+		e.SetUint(n)
+	}
+}
+
+// mapValue 按长度规则生成 map, 只支持字符串及整数的键
+func (g *sampler) mapValue(v reflect.Value, rules RuleSet, length int) {
+	kt, et := v.Type().Key(), v.Type().Elem()
+	if kt.Kind() != reflect.String && !isIntKind(kt.Kind()) {
+		return
+	}
+	n := g.length(rules, 1, 2, length)
+	v.Set(reflect.MakeMapWithSize(v.Type(), n))
+	for i := 1; i <= n; i++ {
+		k := reflect.New(kt).Elem()
+		if kt.Kind() == reflect.String {
+			k.SetString("k" + strconv.Itoa(i))
+		} else {
+			k.SetInt(int64(i))
+		}
+		e, _ := g.candidate(et, RuleSet{{Name: "required"}}, -1)
+		if !e.IsValid() {
+			e = reflect.Zero(et)
+		}
+		v.SetMapIndex(k, e)
+	}
+}
+
+// invalidStrings 格式规则的无效值
+var invalidStrings = map[string][]string{
+	"email":   {"invalid", "a@", "@example.com"},
+	"mobile":  {"12345678901", "1380013800"},
+	"idCard":  {"110105199001011230", "123456"},
+	"url":     {"invalid", "example"},
+	"ip":      {"256.1.1.1", "1.2.3"},
+	"base64":  {"!!!!", "abc"},
+	"numeric": {"12a", "a"},
+	"date":    {"invalid", "20261345"},
+	"regex":   {"!", "~~"},
+}
+
+// invalid 违反 rule 的第 attempt 个候选值, cur 为通过验证的值
+func (g *sampler) invalid(t reflect.Type, cur reflect.Value, rules RuleSet, rule Rule, attempt int) (reflect.Value, bool) {
+	et := sampleElem(t)
+	a := int64(attempt)
+	switch rule.Name {
+	case "required":
+		return reflect.Zero(t), attempt == 0
+	case "empty":
+		others := append(without(rules, "empty"), Rule{Name: "required"})
+		v, err := g.candidate(t, others, -1)
+		return v, err == nil && !v.IsZero()
+	case "gt", "gte", "lt", "lte", "len":
+		p, err := strconv.ParseFloat(rule.Param(), 64)
+		if err != nil {
+			return cur, false
+		}
+		// 依次远离边界
+		n := p
+		switch rule.Name {
+		case "gt":
+			n = p - float64(a)
+		case "gte":
+			n = p - 1 - float64(a)
+		case "lt":
+			n = p + float64(a)
+		default:
+			n = p + 1 + float64(a)
+		}
+		switch et.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map:
+			if n < 0 || n != math.Trunc(n) {
+				return cur, false
+			}
+			v, err := g.candidate(t, without(rules, "gt", "gte", "lt", "lte", "len"), int(n))
+			return v, err == nil
+		}
+		if n == 0 {
+			return cur, false
+		}
+		e := reflect.New(et).Elem()
+		switch {
+		case isIntKind(et.Kind()):
+			if e.OverflowInt(int64(n)) {
+				return cur, false
+			}
+			e.SetInt(int64(n))
+		case isUintKind(et.Kind()):
+			if n < 0 || e.OverflowUint(uint64(n)) {
+				return cur, false
+			}
+			e.SetUint(uint64(n))
+		case isFloatKind(et.Kind()):
+			e.SetFloat(n)
+		default:
+			return cur, false
+		}
+		return wrap(t, e), true
+	case "in", "enum":
+		e := reflect.New(et).Elem()
+		if et.Kind() == reflect.Slice {
+			// 替换第一个元素
+			if !indirect(cur).IsValid() || indirect(cur).Len() == 0 {
+				return cur, false
+			}
+			v := copySlice(indirect(cur))
+			x, ok := g.invalid(et.Elem(), v.Index(0), RuleSet{rule}, rule, attempt)
+			if !ok {
+				return cur, false
+			}
+			v.Index(0).Set(x)
+			return wrap(t, v), true
+		}
+		switch {
+		case et.Kind() == reflect.String:
+			e.SetString("x" + g.chars(sampleLetters, g.length(rules, 1, 8, -1)))
+		case isIntKind(et.Kind()) || isUintKind(et.Kind()):
+			// 大于所有值, 或者 -1, 1000 等
+			n := int64(1000) + a
+			switch attempt {
+			case 0:
+				n = g.maxValue(et, rules) + 1
+			case 1:
+				n = -1
+			}
+			if isUintKind(et.Kind()) && n < 0 {
+				return cur, false
+			}
+			setString(e, strconv.FormatInt(n, 10))
+		default:
+			return cur, false
+		}
+		return wrap(t, e), true
+	case "sin":
+		if !indirect(cur).IsValid() || indirect(cur).Len() == 0 {
+			return cur, false
+		}
+		v := copySlice(indirect(cur))
+		e := v.Index(g.rand.Intn(v.Len()))
+		if e.Kind() == reflect.String {
+			e.SetString("x" + g.chars(sampleLetters, 4))
+		} else {
+			setString(e, strconv.FormatInt(g.maxValue(et.Elem(), RuleSet{rule})+1+a, 10))
+		}
+		return wrap(t, v), true
+	case "distinct":
+		c := indirect(cur)
+		if !c.IsValid() || c.Len() == 0 || attempt > 0 {
+			return cur, false
+		}
+		v := copySlice(c)
+		if v.Len() == 1 {
+			v = reflect.Append(v, v.Index(0))
+		} else {
+			v.Index(v.Len() - 1).Set(v.Index(0))
+		}
+		return wrap(t, v), true
+	case "strGte", "strLte":
+		// 从末尾开始依次将参数的一个字符减一 (strGte) 或加一 (strLte), 如 2024-01-01 => 2023-01-01
+		p := rule.Param()
+		i := len(p) - 1 - attempt
+		if et.Kind() != reflect.String || i < 0 {
+			return cur, false
+		}
+		c := p[i] + 1
+		if rule.Name == "strGte" {
+			c = p[i] - 1
+		}
+		if p[i] >= utf8.RuneSelf || c < '!' || c > '~' {
+			return cur, false
+		}
+		e := reflect.New(et).Elem()
+		e.SetString(p[:i] + string(c) + p[i+1:])
+		return wrap(t, e), true
+	}
+
+	// 格式规则
+	if et.Kind() != reflect.String {
+		return cur, false
+	}
+	s := ""
+	if list := invalidStrings[rule.Name]; attempt < len(list) {
+		s = list[attempt]
+	} else {
+		n := g.length(rules, 1, 8, -1)
+		s = g.chars(sampleLetters+sampleDigits+"!~-", n)
+	}
+	e := reflect.New(et).Elem()
+	e.SetString(s)
+	return wrap(t, e), true
+}
+
+// maxValue in, sin, enum 中最大的整数
+func (g *sampler) maxValue(t reflect.Type, rules RuleSet) int64 {
+	var values []string
+	for _, r := range rules {
+		if r.Name == "in" || r.Name == "sin" {
+			values = append(values, r.Values()...)
+		}
+	}
+	if enums, ok := enumValues(t, rules); ok {
+		for _, e := range enums {
+			values = append(values, fmt.Sprint(e))
+		}
+	}
+	max := int64(0)
+	for _, s := range values {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil && n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+// copySlice 复制 slice 或数组
+func copySlice(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Array {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		return c
+	}
+	c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(c, v)
+	return c
+}
+
+// without 去除 names 的规则
+func without(rules RuleSet, names ...string) RuleSet {
+	var rs RuleSet
+	for _, r := range rules {
+		skip := false
+		for _, name := range names {
+			skip = skip || r.Name == name
+		}
+		if !skip {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+// atLeast 不小于 min 的 n
+func atLeast(n, min int) int {
+	if n < min {
+		return min
+	}
+	return n
+}
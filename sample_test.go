@@ -0,0 +1,122 @@
+package gvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/24 11:00
+ * @Desc:
+ */
+
+func TestSampleValid(t *testing.T) {
+	RegisterEnum("OrderStatus", OrderStatusPaid, OrderStatusShipped, OrderStatusDone)
+	defer delete(enums, "OrderStatus")
+
+	Convey("test sample valid", t, func() {
+		for _, groups := range [][]string{nil, {"create"}} {
+			s := &Sample{Seed: 1, Groups: groups}
+			form := &clientForm{}
+			So(s.Valid(form), ShouldBeNil)
+			valid := &Validation{Groups: groups}
+			ok, err := valid.Valid(form)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			// 必填的字段都有值, 格式规则生成符合格式的值
+			So(form.Name, ShouldNotBeEmpty)
+			So(form.Gallery, ShouldNotBeNil)
+			So(form.Count, ShouldNotBeNil)
+			So(form.Tags, ShouldNotBeEmpty)
+			So(form.Id, ShouldEqual, 0)
+			So(emailPattern.MatchString(form.Email), ShouldBeTrue)
+			So(ValidIdCardCode(form.IdCard), ShouldBeTrue)
+			So([]string{"a b", "c"}, ShouldContain, form.Kind)
+			So(form.Remark.Valid, ShouldBeTrue)
+			// json:"-" 的字段同样验证
+			So(form.Secret, ShouldNotBeEmpty)
+		}
+	})
+
+	Convey("test sample is deterministic", t, func() {
+		data := func(seed int64) string {
+			form := &clientForm{}
+			So((&Sample{Seed: seed}).Valid(form), ShouldBeNil)
+			b, err := json.Marshal(form)
+			So(err, ShouldBeNil)
+			return string(b)
+		}
+		So(data(1), ShouldEqual, data(1))
+		So(data(1), ShouldNotEqual, data(2))
+	})
+
+	Convey("test sample errors", t, func() {
+		So((&Sample{}).Valid(clientForm{}), ShouldNotBeNil)
+		So((&Sample{}).Valid(nil), ShouldNotBeNil)
+		_, err := (&Sample{}).Invalid(1)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestSampleInvalid(t *testing.T) {
+	RegisterEnum("OrderStatus", OrderStatusPaid, OrderStatusShipped, OrderStatusDone)
+	defer delete(enums, "OrderStatus")
+
+	Convey("test sample invalid", t, func() {
+		samples, err := (&Sample{Seed: 1, Groups: []string{"create"}}).Invalid(clientForm{})
+		So(err, ShouldBeNil)
+		var targets []string
+		for _, sample := range samples {
+			targets = append(targets, sample.Path+" "+sample.Rule)
+
+			valid := &Validation{Groups: []string{"create"}}
+			_, err = valid.Valid(sample.Value)
+			So(err, ShouldBeNil)
+			var errs []string
+			for _, e := range valid.Errors {
+				errs = append(errs, e.Path+" "+e.Rule)
+			}
+			expected := []string{sample.Path + " " + sample.Rule}
+			if sample.Path == "Gallery" {
+				// dive 的 nil 指针验证其零值
+				expected = append(expected, "Gallery.ImgUrl required")
+			}
+			So(errs, ShouldResemble, expected)
+		}
+		So(targets, ShouldResemble, []string{
+			"Cate required", "Cate gt", "Name required", "Name lte", "Gallery required", "Gallery.ImgUrl required",
+			"List[0].ImgUrl required", "Id empty", "Title required", "Title gt", "Title lte", "Kind in", "Level in",
+			"Price gt", "Price lt", "Rate lte", "Count required", "Count gte", "Email email", "Mobile mobile",
+			"IdCard idCard", "Date date", "Date strGte", "Date strLte", "Code regex", "Tags required", "Tags distinct", "Tags sin",
+			"Ids distinct", "Ids sin", "Status enum", "Statuses enum", "Meta lte", "Password gte",
+			"Address.City required", "Address.City lte", "Address.Zip len", "Address.Zip numeric",
+			"Backups[0].City required", "Backups[0].City lte", "Backups[0].Zip len", "Backups[0].Zip numeric",
+			"Remark required", "Secret required",
+		})
+	})
+
+	Convey("test sample invalid values are the smallest violations", t, func() {
+		samples, err := (&Sample{Seed: 1}).Invalid(&jsonGoods{})
+		So(err, ShouldBeNil)
+		values := map[string]string{}
+		for _, sample := range samples {
+			b, err := json.Marshal(sample.Value)
+			So(err, ShouldBeNil)
+			var m map[string]interface{}
+			So(json.Unmarshal(b, &m), ShouldBeNil)
+			if v, ok := m[map[string]string{"Cate": "cate", "Name": "name", "Stock": "stock"}[sample.Path]]; ok {
+				values[sample.Path+" "+sample.Rule] = fmt.Sprint(v)
+			}
+		}
+		So(values["Cate gt"], ShouldEqual, "-1")
+		So(values["Stock gte"], ShouldEqual, "-1")
+		So(values["Cate required"], ShouldEqual, "0")
+		So([]rune(values["Name lte"]), ShouldHaveLength, 11)
+	})
+}
@@ -0,0 +1,302 @@
+package gvalid
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 17:20
+ * @Desc: tag 解析结果, 用于文档, 表单等工具
+ */
+
+// ParamType 参数类型
+type ParamType string
+
+const (
+	ParamString ParamType = "string"
+	ParamInt    ParamType = "int"
+	ParamFloat  ParamType = "float"
+	// ParamRegex regex 的正则表达式
+	ParamRegex ParamType = "regex"
+	// ParamLayout date 的时间格式
+	ParamLayout ParamType = "layout"
+)
+
+// Param 规则参数
+type Param struct {
+	Value string
+	Type  ParamType
+}
+
+// Int 整数参数
+func (p Param) Int() (int64, error) {
+	return strconv.ParseInt(p.Value, 10, 64)
+}
+
+// Float 浮点数参数
+func (p Param) Float() (float64, error) {
+	return strconv.ParseFloat(p.Value, 64)
+}
+
+// Rule 一条验证规则, 如 in='a b',c@create
+type Rule struct {
+	// Name tag 中的规则名, 如 required, idCard
+	Name string
+	// Params 参数, 参数为列表的规则 (in, sin) 每个值一个参数, 其他规则最多一个参数, 如 date=2006-01-02 15:04:05
+	Params []Param
+	// Groups 规则所属分组, 为空时所有分组都验证
+	Groups []string
+	// Column 规则在 tag 中的位置, 从 1 开始, 按字符计
+	Column int
+}
+
+// Values 参数值
+func (r Rule) Values() []string {
+	if len(r.Params) == 0 {
+		return nil
+	}
+	values := make([]string, len(r.Params))
+	for i, p := range r.Params {
+		values[i] = p.Value
+	}
+	return values
+}
+
+// Param 空格拼接后的参数值
+func (r Rule) Param() string {
+	return strings.Join(r.Values(), " ")
+}
+
+// funcParam 传给验证函数的参数, in, sin 为可以再次解析的参数列表
+func (r Rule) funcParam() string {
+	if listRules[r.Name] {
+		return joinParams(r.Values())
+	}
+	return r.Param()
+}
+
+// QuotedParams 用于错误信息的参数, 同 in, sin 的错误信息, 多个参数时含空格或逗号的参数加引号
+func (r Rule) QuotedParams() string {
+	return quoteParams(r.Values())
+}
+
+// RuleSet 字段的所有规则
+type RuleSet []Rule
+
+// Get 获取规则, 有多条同名规则时返回第一条
+func (rs RuleSet) Get(name string) (Rule, bool) {
+	for _, r := range rs {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Has 是否有该规则
+func (rs RuleSet) Has(name string) bool {
+	_, ok := rs.Get(name)
+	return ok
+}
+
+// ForGroups 验证场景 groups 下执行的规则, 即未分组及属于 groups 的规则, 同 Validation.Groups
+func (rs RuleSet) ForGroups(groups ...string) RuleSet {
+	valid := &Validation{Groups: groups}
+	var active RuleSet
+	for _, r := range rs {
+		if valid.inGroups(r.Groups) {
+			active = append(active, r)
+		}
+	}
+	return active
+}
+
+func (rs RuleSet) validFuncs() (vfs []ValidFunc) {
+	for _, r := range rs {
+		vfs = append(vfs, ValidFunc{Name: validFuncPrefix + toUpperCamel(r.Name), Params: r.funcParam(), Values: r.Values(), Groups: r.Groups})
+	}
+	return
+}
+
+// TypeSchema 结构体的验证规则
+type TypeSchema struct {
+	Name    string
+	PkgPath string
+	// Type 通过 DescribeType 获取时为结构体类型
+	Type   reflect.Type
+	Fields []*FieldSchema
+}
+
+// FieldSchema 字段的验证规则
+type FieldSchema struct {
+	// Name Go 字段名
+	Name string
+	// Label name tag
+	Label string
+	// JSONName json 字段名, json:"-" 和展开的匿名结构体为空
+	JSONName  string
+	Type      reflect.Type
+	Anonymous bool
+	Rules     RuleSet
+	// Dive 有 dive 规则时, 结构体或其指针, slice 元素的验证规则
+	// 展开的匿名结构体 (JSONName 为空) 没有 dive 时同样有 Dive, 同 encoding/json 其字段属于外层结构体
+	Dive *TypeSchema
+}
+
+// ParseTag 解析 valid tag, 语法错误时返回 *TagError
+func ParseTag(tag string) (RuleSet, error) {
+	return parseRules(tag)
+}
+
+// DescribeType 获取结构体的验证规则, 包含 dive 的结构体
+// 同一类型只解析一次, 递归的类型 Dive 指向同一个 *TypeSchema
+func DescribeType(t reflect.Type) (*TypeSchema, error) {
+	return describeType(t, make(map[reflect.Type]*TypeSchema))
+}
+
+func describeType(t reflect.Type, seen map[reflect.Type]*TypeSchema) (*TypeSchema, error) {
+	if t == nil || !isStructOrStructPtr(t) {
+		return nil, fmt.Errorf("%v 必须是 结构体 或者 结构体指针", t)
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if s, ok := seen[t]; ok {
+		return s, nil
+	}
+
+	s := &TypeSchema{Name: t.Name(), PkgPath: t.PkgPath(), Type: t}
+	seen[t] = s
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		rules, err := fieldRules(t, f)
+		if err != nil {
+			return nil, err
+		}
+		fs := &FieldSchema{
+			Name:      f.Name,
+			Label:     labeledField(t, f).Tag.Get(defaultNameTag),
+			JSONName:  jsonName(f),
+			Type:      f.Type,
+			Anonymous: f.Anonymous,
+			Rules:     rules,
+		}
+		if rules.Has(ruleName(diveFunc)) || (f.Anonymous && fs.JSONName == "") {
+			if et := diveElem(f.Type); isStruct(et) {
+				if fs.Dive, err = describeType(et, seen); err != nil {
+					return nil, err
+				}
+			}
+		}
+		s.Fields = append(s.Fields, fs)
+	}
+	return s, nil
+}
+
+// fieldRules 字段的验证规则, 包括 Rules 注册的规则及当前的覆盖规则, 语法错误时返回 *TagError
+func fieldRules(t reflect.Type, f reflect.StructField) (rules RuleSet, err error) {
+	return overriddenRules(t, f, loadOverrides())
+}
+
+// overriddenRules 按 tag, Rules 注册的规则, 覆盖规则 ov 的顺序合并
+func overriddenRules(t reflect.Type, f reflect.StructField, ov *ruleOverrides) (rules RuleSet, err error) {
+	if rules, err = tagRules(t, f); err != nil {
+		return
+	}
+	if rf := registeredRules(t, f.Name); rf != nil {
+		rules = mergeRules(rules, rf.rules)
+	}
+	return ov.overrideRules(t, f.Name, rules), nil
+}
+
+// tagRules tag 中的验证规则, 没有 tag 的匿名结构体为 dive
+func tagRules(t reflect.Type, f reflect.StructField) (rules RuleSet, err error) {
+	tag := f.Tag.Get(defaultTagName)
+	if f.Anonymous && isStructOrStructPtr(f.Type) && tag == "" {
+		return RuleSet{{Name: ruleName(diveFunc)}}, nil
+	}
+	if tag == "" || tag == skipValidationTag {
+		return
+	}
+
+	if rules, err = parseRules(tag); err != nil {
+		if e, ok := err.(*TagError); ok {
+			if e.Struct = t.Name(); e.Struct == "" {
+				e.Struct = t.String()
+			}
+			e.Field = f.Name
+		}
+		return
+	}
+
+	// groups tag 作用于没有指定分组的规则
+	if groups := f.Tag.Get(groupsTagName); groups != "" {
+		for i := range rules {
+			if len(rules[i].Groups) == 0 {
+				rules[i].Groups = splitGroups(groups, tagSep)
+			}
+		}
+	}
+	return
+}
+
+// newParams 按规则确定参数及其类型, 参数为 string 的规则拼接为一个参数
+func newParams(rule string, values []string) (params []Param) {
+	if len(values) == 0 {
+		return
+	}
+	if !listRules[rule] && len(values) > 1 {
+		values = []string{strings.Join(values, " ")}
+	}
+	for _, v := range values {
+		p := Param{Value: v, Type: ParamString}
+		switch {
+		case rule == toLowerCamel(RegexFunc):
+			p.Type = ParamRegex
+		case rule == "date":
+			p.Type = ParamLayout
+		case rule == "strGte" || rule == "strLte":
+			// 按字典序比较, 如 2024-01-01, 不解析为数字
+		default:
+			if _, err := p.Int(); err == nil {
+				p.Type = ParamInt
+			} else if _, err = p.Float(); err == nil {
+				p.Type = ParamFloat
+			}
+		}
+		params = append(params, p)
+	}
+	return
+}
+
+// diveElem dive 的元素类型, 去除指针, slice 和 array
+func diveElem(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t
+}
+
+// jsonName json 字段名
+func jsonName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" && f.Anonymous && isStructOrStructPtr(f.Type) {
+		return ""
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return name
+}
@@ -0,0 +1,89 @@
+package gvalid
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 17:50
+ * @Desc:
+ */
+
+type schemaNode struct {
+	Title    string        `json:"title" valid:"required,lte=20" name:"标题" groups:"create"`
+	Size     string        `json:"-" valid:"in='X L' M,date=2006-01-02 15:04:05"`
+	Children []*schemaNode `json:"children,omitempty" valid:"dive"`
+}
+
+func TestParseTagPublic(t *testing.T) {
+	Convey("test ParseTag", t, func() {
+		rules, err := ParseTag("required@create,gt=1.5,in='a b' 2,regex=(/^\\d+$/)")
+		So(err, ShouldBeNil)
+		So(rules, ShouldResemble, RuleSet{
+			{Name: "required", Groups: []string{"create"}, Column: 1},
+			{Name: "gt", Params: []Param{{"1.5", ParamFloat}}, Column: 17},
+			{Name: "in", Params: []Param{{"a b", ParamString}, {"2", ParamInt}}, Column: 24},
+			{Name: "regex", Params: []Param{{`^\d+$`, ParamRegex}}, Column: 35},
+		})
+		So(rules.Has("in"), ShouldBeTrue)
+		r, _ := rules.Get("gt")
+		f, _ := r.Params[0].Float()
+		So(f, ShouldEqual, 1.5)
+
+		_, err = ParseTag("in='a")
+		So(errors.Is(err, InvalidExpr), ShouldBeTrue)
+	})
+}
+
+func TestDescribeType(t *testing.T) {
+	Convey("test DescribeType", t, func() {
+		s, err := DescribeType(reflect.TypeOf(&schemaNode{}))
+		So(err, ShouldBeNil)
+		So(s.Name, ShouldEqual, "schemaNode")
+		So(s.PkgPath, ShouldEqual, "github.com/booldesign/gvalid")
+		So(len(s.Fields), ShouldEqual, 3)
+
+		title := s.Fields[0]
+		So(title.Label, ShouldEqual, "标题")
+		So(title.JSONName, ShouldEqual, "title")
+		So(title.Rules, ShouldResemble, RuleSet{
+			{Name: "required", Groups: []string{"create"}, Column: 1},
+			{Name: "lte", Params: []Param{{"20", ParamInt}}, Groups: []string{"create"}, Column: 10},
+		})
+
+		size := s.Fields[1]
+		So(size.JSONName, ShouldEqual, "")
+		So(size.Rules[1].Params, ShouldResemble, []Param{{"2006-01-02 15:04:05", ParamLayout}})
+
+		// 递归的类型指向同一个 TypeSchema
+		So(s.Fields[2].Dive, ShouldEqual, s)
+	})
+
+	Convey("test DescribeType embedded", t, func() {
+		s, err := DescribeType(reflect.TypeOf(jsonGoods{}))
+		So(err, ShouldBeNil)
+		spu := s.Fields[0]
+		So(spu.Anonymous, ShouldBeTrue)
+		So(spu.Dive.Name, ShouldEqual, "jsonSpu")
+		So(spu.Dive.Fields[2].Dive.Fields[0].Name, ShouldEqual, "ImgUrl")
+		So(spu.Dive.Fields[3].Dive, ShouldEqual, spu.Dive.Fields[2].Dive)
+	})
+
+	Convey("test DescribeType error", t, func() {
+		_, err := DescribeType(reflect.TypeOf(1))
+		So(err, ShouldNotBeNil)
+
+		type Bad struct {
+			Name string `valid:"required,"`
+			Code string `valid:"in='a"`
+		}
+		_, err = DescribeType(reflect.TypeOf(Bad{}))
+		So(err.(*TagError).Field, ShouldEqual, "Code")
+	})
+}
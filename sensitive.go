@@ -0,0 +1,241 @@
+package gvalid
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/19 14:20
+ * @Desc: 敏感字段脱敏
+ */
+
+const (
+	MaskFull   = "full"
+	MaskMobile = "mobile"
+	MaskIdCard = "idCard"
+)
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// Masker 脱敏函数
+type Masker func(s string) string
+
+var (
+	maskers = map[string]Masker{
+		MaskFull:   maskFull,
+		MaskMobile: maskKeep(3, 4),
+		MaskIdCard: maskKeep(6, 4),
+	}
+
+	// sensitiveFields 全局脱敏字段, key 为小写的字段名
+	sensitiveFields = map[string]string{
+		"password":   MaskFull,
+		"repassword": MaskFull,
+		"mobile":     MaskMobile,
+		"idcard":     MaskIdCard,
+	}
+
+	// sensitiveMu 保护 maskers 和 sensitiveFields, 验证过程中可以注册
+	sensitiveMu sync.RWMutex
+)
+
+// RegisterMasker 注册脱敏方式, 用于 valid:"sensitive=name"
+func RegisterMasker(name string, m Masker) {
+	sensitiveMu.Lock()
+	defer sensitiveMu.Unlock()
+	maskers[name] = m
+}
+
+// SetSensitiveField 设置全局脱敏字段, 字段名不区分大小写, masker 为空时不脱敏
+// 默认: Password, RePassword 全部隐藏, Mobile 保留前 3 后 4 位, IdCard 保留前 6 后 4 位
+func SetSensitiveField(fieldName, masker string) {
+	sensitiveMu.Lock()
+	defer sensitiveMu.Unlock()
+	if masker == "" {
+		delete(sensitiveFields, strings.ToLower(fieldName))
+		return
+	}
+	sensitiveFields[strings.ToLower(fieldName)] = masker
+}
+
+// maskerOf 注册的脱敏方式
+func maskerOf(name string) (m Masker, ok bool) {
+	sensitiveMu.RLock()
+	defer sensitiveMu.RUnlock()
+	m, ok = maskers[name]
+	return
+}
+
+// sensitiveField 全局脱敏字段的脱敏方式, 字段名不区分大小写
+func sensitiveField(fieldName string) (masker string, ok bool) {
+	sensitiveMu.RLock()
+	defer sensitiveMu.RUnlock()
+	masker, ok = sensitiveFields[strings.ToLower(fieldName)]
+	return
+}
+
+// maskFull 全部隐藏, 不暴露长度
+func maskFull(string) string {
+	return "******"
+}
+
+// maskKeep 保留前 head 位和后 tail 位, 长度不足时全部隐藏
+func maskKeep(head, tail int) Masker {
+	return func(s string) string {
+		n := utf8.RuneCountInString(s)
+		if n <= head+tail {
+			return maskFull(s)
+		}
+		r := []rune(s)
+		return string(r[:head]) + strings.Repeat("*", n-head-tail) + string(r[n-tail:])
+	}
+}
+
+// fieldMasker 字段的脱敏函数, valid tag 中的 sensitive 优先, 其次为全局脱敏字段
+func fieldMasker(f reflect.StructField, vfs []ValidFunc) (m Masker, err error) {
+	name, ok := "", false
+	for _, vf := range vfs {
+		if vf.Name == validFuncPrefix+toUpperCamel(SensitiveTag) {
+			if name, ok = vf.Param(), true; name == "" {
+				name = MaskFull
+			}
+		}
+	}
+	if !ok {
+		if name, ok = sensitiveField(f.Name); !ok {
+			return
+		}
+	}
+	if m, ok = maskerOf(name); !ok {
+		err = fmt.Errorf("%s: 未知的脱敏方式 %s", SensitiveTag, name)
+	}
+	return
+}
+
+// maskValue 脱敏字段值, slice 和 array 对每个元素脱敏
+func maskValue(v interface{}, m Masker) interface{} {
+	vOf := reflect.ValueOf(v)
+	switch vOf.Kind() {
+	case reflect.Slice, reflect.Array:
+		vs := make([]string, vOf.Len())
+		for i := range vs {
+			vs[i] = m(fmt.Sprint(indirect(vOf.Index(i))))
+		}
+		return vs
+	case reflect.Invalid:
+		return v
+	default:
+		return m(fmt.Sprint(v))
+	}
+}
+
+// display 错误信息中显示的字段值, 当前字段需要脱敏时返回脱敏后的值
+func (valid *Validation) display(v interface{}) interface{} {
+	if valid.mask == nil {
+		return v
+	}
+	return maskValue(v, valid.mask)
+}
+
+// Mask 返回当前结构体中 fieldName 字段的值 v 用于错误信息的形式, 敏感字段返回脱敏后的值
+// ValidCustom 中将字段值写入错误信息时使用, 如 valid.SetError("Password", "密码", valid.Mask("Password", f.Password)+" 过于简单")
+func (valid *Validation) Mask(fieldName string, v interface{}) string {
+	if valid.parent != nil {
+		if f, ok := valid.parent.FieldByName(fieldName); ok {
+			if m := structFieldMasker(valid.parent, f, valid.overrides); m != nil {
+				return fmt.Sprint(maskValue(v, m))
+			}
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+// structFieldMasker 结构体 t 中字段 f 的脱敏函数, 没有或脱敏方式未知时返回 nil
+func structFieldMasker(t reflect.Type, f reflect.StructField, ov *ruleOverrides) Masker {
+	if ov == nil {
+		ov = loadOverrides()
+	}
+	vfs, err := matchValidFunc(t, f, ov)
+	if err != nil {
+		return nil
+	}
+	m, _ := fieldMasker(f, vfs)
+	return m
+}
+
+// maskedValue m 不为 nil 时脱敏
+func maskedValue(v interface{}, m Masker) interface{} {
+	if m == nil || v == nil {
+		return v
+	}
+	return maskValue(v, m)
+}
+
+// keyMasker 未知字段按全局脱敏字段脱敏
+func keyMasker(key string) Masker {
+	if name, ok := sensitiveField(key); ok {
+		m, _ := maskerOf(name)
+		return m
+	}
+	return nil
+}
+
+// Redact 输出脱敏后的结构体, 用于日志等调试输出, 规则与 Error.Value 一致
+func Redact(obj interface{}) string {
+	vOf := indirect(reflect.ValueOf(obj))
+	if !vOf.IsValid() || vOf.Kind() != reflect.Struct {
+		return fmt.Sprintf("%+v", obj)
+	}
+	return redactStruct(vOf)
+}
+
+func redactStruct(vOf reflect.Value) string {
+	tOf := vOf.Type()
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i < tOf.NumField(); i++ {
+		f := tOf.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		if b.Len() > 1 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(f.Name)
+		b.WriteByte(':')
+
+		fv := indirect(vOf.Field(i))
+		switch {
+		case !fv.IsValid():
+			b.WriteString("<nil>")
+		case !fv.CanInterface():
+			b.WriteString("-")
+		case fv.Kind() == reflect.Struct && !fv.Type().Implements(stringerType):
+			b.WriteString(redactStruct(fv))
+		case fv.Kind() == reflect.Slice && fv.Len() > 0 && indirect(fv.Index(0)).Kind() == reflect.Struct:
+			b.WriteByte('[')
+			for j := 0; j < fv.Len(); j++ {
+				if j > 0 {
+					b.WriteByte(' ')
+				}
+				b.WriteString(redactStruct(indirect(fv.Index(j))))
+			}
+			b.WriteByte(']')
+		default:
+			vfs, _ := matchValidFunc(tOf, f, loadOverrides())
+			if m, err := fieldMasker(f, vfs); m != nil && err == nil && !fv.IsZero() {
+				b.WriteString(fmt.Sprint(maskValue(fv.Interface(), m)))
+			} else {
+				b.WriteString(fmt.Sprint(fv.Interface()))
+			}
+		}
+	}
+	b.WriteByte('}')
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package gvalid
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/19 15:02
+ * @Desc:
+ */
+
+func TestSensitive(t *testing.T) {
+	type Contact struct {
+		Phone string `valid:"len=11,sensitive=mobile" name:"电话"`
+	}
+	type WUser struct {
+		Password string   `valid:"gte=8" name:"密码"`
+		Mobile   string   `valid:"len=12" name:"手机"`
+		IdCard   string   `valid:"len=15" name:"身份证"`
+		Secret   []string `valid:"distinct,sensitive" name:"密钥"`
+		Contact  *Contact `valid:"dive" name:"联系人"`
+		Nickname string   `valid:"len=10" name:"昵称"`
+	}
+
+	u := &WUser{
+		Password: "1234567",
+		Mobile:   "13501691436",
+		IdCard:   "310104200312166537",
+		Secret:   []string{"abc", "abc"},
+		Contact:  &Contact{Phone: "021-12345678"},
+		Nickname: "wei",
+	}
+	v := &Validation{}
+	b, err := v.Valid(u)
+	if err != nil {
+		t.Fatal("result err:", err)
+	}
+
+	Convey("test sensitive", t, func() {
+		So(b, ShouldBeFalse)
+		So(v.ErrorsMap["Password"][0].Value, ShouldEqual, "******")
+		So(v.ErrorsMap["Mobile"][0].Value, ShouldEqual, "135****1436")
+		So(v.ErrorsMap["IdCard"][0].Value, ShouldEqual, "310104********6537")
+		So(v.ErrorsMap["Secret"][0].Value, ShouldResemble, []string{"******", "******"})
+		So(v.ErrorsMap["Secret"][0].Message, ShouldEqual, "含有重复的值 [****** ******]")
+		So(v.ErrorsByPath["Contact.Phone"][0].Value, ShouldEqual, "021*****5678")
+		So(v.ErrorsMap["Nickname"][0].Value, ShouldEqual, "wei")
+	})
+
+	Convey("test redact", t, func() {
+		So(Redact(u), ShouldEqual, "{Password:****** Mobile:135****1436 IdCard:310104********6537 Secret:[****** ******] Contact:{Phone:021*****5678} Nickname:wei}")
+	})
+
+	Convey("test unknown masker", t, func() {
+		type WUser struct {
+			Name string `valid:"sensitive=foo" name:"姓名"`
+		}
+		_, err := (&Validation{}).Valid(&WUser{})
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("test concurrent registration", t, func() {
+		defer func() {
+			sensitiveMu.Lock()
+			delete(maskers, "secret")
+			delete(sensitiveFields, "secret")
+			sensitiveMu.Unlock()
+		}()
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				RegisterMasker("secret", maskKeep(1, 1))
+				SetSensitiveField("Secret", "secret")
+			}()
+			go func() {
+				defer wg.Done()
+				_, _ = (&Validation{}).Valid(u)
+			}()
+		}
+		wg.Wait()
+		So(Redact(&struct{ Secret string }{Secret: "abcdef"}), ShouldEqual, "{Secret:a****f}")
+	})
+}
+
+type maskForm struct {
+	Password string `valid:"gte=10" name:"密码"`
+	Mobile   string `json:"mobile" name:"手机"`
+}
+
+// Valid 错误信息中的字段值使用 Mask 脱敏
+func (f *maskForm) Valid(valid *Validation) {
+	if f.Password == "1" {
+		valid.SetError("Password", "密码", valid.Mask("Password", f.Password)+" 过于简单")
+	}
+}
+
+func TestSensitiveMessage(t *testing.T) {
+	Convey("test message not corrupted", t, func() {
+		v := &Validation{}
+		_, err := v.Valid(&maskForm{Password: "1"})
+		So(err, ShouldBeNil)
+		So(v.ErrorsMap["Password"][0].Message, ShouldEqual, "长度必须是大于等于 10")
+		So(v.ErrorsMap["Password"][0].Value, ShouldEqual, "******")
+		So(v.ErrorsMap["Password"][1].Message, ShouldEqual, "****** 过于简单")
+	})
+
+	Convey("test json decode errors", t, func() {
+		v := &Validation{DisallowUnknownFields: true}
+		b, err := v.ValidateJSON([]byte(`{"password":123456789,"mobile":13501691436,"idCard":310104200312166537}`), &maskForm{})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		values := map[string]interface{}{}
+		for _, e := range v.Errors {
+			values[e.Path] = e.Value
+		}
+		So(values, ShouldResemble, map[string]interface{}{
+			"Password": "******",
+			"Mobile":   "135****1436",
+			"idCard":   "310104********6537",
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package gvalid
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 10:50
+ * @Desc:
+ */
+
+// sdkPeriod 模拟无法添加方法的第三方类型
+type sdkPeriod struct {
+	Begin string `valid:"required,date=2006-01-02" name:"开始日期"`
+	End   string `valid:"required,date=2006-01-02" name:"结束日期"`
+}
+
+type sdkCoupon struct {
+	Code string `valid:"required" name:"券码"`
+}
+
+type sdkActivity struct {
+	Name    string       `valid:"required" name:"活动名称"`
+	Period  sdkPeriod    `valid:"dive" name:"活动时间"`
+	Periods []*sdkPeriod `valid:"dive" name:"分段时间"`
+}
+
+func TestRegisterStructValidation(t *testing.T) {
+	var calls int
+	RegisterStructValidation(func(v *Validation, obj interface{}) {
+		calls++
+		p := obj.(sdkPeriod)
+		if p.Begin != "" && p.End != "" && p.Begin > p.End {
+			v.SetError("End", "结束日期", "必须大于等于开始日期")
+		}
+	}, &sdkPeriod{})
+	defer func() {
+		structValidationsMu.Lock()
+		delete(structValidations, indirectType(reflect.TypeOf(sdkPeriod{})))
+		structValidationsMu.Unlock()
+	}()
+
+	Convey("test struct validation", t, func() {
+		u := &sdkActivity{
+			Name:    "双11",
+			Period:  sdkPeriod{Begin: "2022-11-11", End: "2022-11-01"},
+			Periods: []*sdkPeriod{{Begin: "2022-11-01", End: "2022-11-02"}, {Begin: "2022-11-03", End: "2022-11-02"}},
+		}
+		v := &Validation{}
+		b, err := v.Valid(u)
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(calls, ShouldEqual, 3)
+		So(errorPaths(v), ShouldResemble, []string{"Period.End", "Periods[1].End"})
+		So(v.ErrorsByPath["Period.End"][0].Rule, ShouldEqual, CustomRule)
+	})
+
+	Convey("test concurrent registration", t, func() {
+		defer func() {
+			structValidationsMu.Lock()
+			delete(structValidations, reflect.TypeOf(sdkCoupon{}))
+			structValidationsMu.Unlock()
+		}()
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				RegisterStructValidation(func(v *Validation, obj interface{}) {}, sdkCoupon{})
+			}()
+			go func() {
+				defer wg.Done()
+				_, _ = (&Validation{}).Valid(&sdkCoupon{Code: "a"})
+			}()
+		}
+		wg.Wait()
+		So(structValidationsOf(reflect.TypeOf(sdkCoupon{})), ShouldHaveLength, 4)
+	})
+}
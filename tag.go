@@ -0,0 +1,398 @@
+package gvalid
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 16:00
+ * @Desc: tag 词法, 语法解析
+ *
+ * tag    = rule { "," rule }
+ * rule   = name [ "=" params ] [ "@" groups ]
+ * params = param { " " param | "," quoted }
+ * param  = quoted | regex | bare
+ * quoted = "'" ... "'"          单引号内 \' 为单引号, \\ 为反斜杠, 可包含空格和逗号
+ * regex  = "(/" ... "/)"        兼容旧写法 regex=(/.../), 原样保留
+ * bare   = 不含空格和逗号的字符, \, \  \' \@ \\ 为转义
+ *          之后紧跟 @ 分组时须为数字, 如 gte=6@create, 否则有歧义, 如 default=me@corp
+ * groups = group { "|" group }
+ */
+
+// TagError tag 语法错误, errors.Is(err, InvalidExpr) 为 true
+type TagError struct {
+	Struct string
+	Field  string
+	Tag    string
+	// Column 出错位置, 从 1 开始, 按字符计
+	Column int
+	Msg    string
+}
+
+func (e *TagError) Error() string {
+	msg := fmt.Sprintf("%s:%q 第 %d 列: %s", defaultTagName, e.Tag, e.Column, e.Msg)
+	if e.Struct == "" && e.Field == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s.%s: %s", e.Struct, e.Field, msg)
+}
+
+func (e *TagError) Unwrap() error {
+	return InvalidExpr
+}
+
+type tagParser struct {
+	tag string
+	s   []rune
+	pos int
+	// cut 上一个参数不带引号且紧跟逗号时为逗号的位置, 否则为 -1, cutStart 为该参数的开始位置
+	// 逗号之后解析失败时提示参数中的逗号须加引号, 如 regex=^[a-z]{1,3}$
+	cut, cutStart int
+}
+
+// parseTag 解析 valid tag 为验证函数, 返回的 *TagError 不含结构体和字段名
+func parseTag(tag string) (vfs []ValidFunc, err error) {
+	rules, err := parseRules(tag)
+	if err != nil {
+		return
+	}
+	return rules.validFuncs(), nil
+}
+
+// parseRules 解析 valid tag, 返回的 *TagError 不含结构体和字段名
+func parseRules(tag string) (rules RuleSet, err error) {
+	p := &tagParser{tag: tag, s: []rune(tag), cut: -1}
+	for {
+		p.skipSpaces()
+		if p.eof() {
+			return
+		}
+		if p.peek() == ',' {
+			p.pos++
+			continue
+		}
+		start, cut := p.pos, p.cut
+		p.cut = -1
+		var r Rule
+		if r, err = p.rule(); err != nil {
+			if cut >= 0 && start == cut+1 {
+				return nil, p.cutError(cut)
+			}
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+}
+
+func (p *tagParser) rule() (r Rule, err error) {
+	start := p.pos
+	for !p.eof() && isNameRune(p.peek()) {
+		p.pos++
+	}
+	r.Name, r.Column = string(p.s[start:p.pos]), start+1
+	if r.Name == "" {
+		return r, p.errorf(p.pos, "缺少规则名")
+	}
+	if c := r.Name[0]; !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+		return r, p.errorf(start, "规则名 %s 无效", r.Name)
+	}
+
+	p.skipSpaces()
+	if !p.eof() && p.peek() == '=' {
+		p.pos++
+		paramStart := p.pos
+		var params []string
+		var text string
+		if params, text, err = p.params(); err != nil {
+			return
+		}
+		// 参数不是列表的规则保留参数之间原有的空白, 如 date 的格式
+		if !listRules[r.Name] && len(params) > 1 {
+			params = []string{text}
+		}
+		r.Params = newParams(r.Name, params)
+		if r.Name == toLowerCamel(RegexFunc) {
+			if _, e := regexp.Compile(r.Param()); e != nil {
+				if p.cut >= 0 {
+					return r, p.errorf(paramStart, "正则表达式错误: %v, 参数中含逗号时请使用引号, 如 %s", e, p.cutQuoted(p.cut))
+				}
+				return r, p.errorf(paramStart, "正则表达式错误: %v", e)
+			}
+		}
+	}
+	if !p.eof() && p.peek() == '@' {
+		if r.Groups, err = p.groups(); err != nil {
+			return
+		}
+	}
+
+	p.skipSpaces()
+	if !p.eof() && p.peek() != ',' {
+		return r, p.errorf(p.pos, "多余的字符 %q", p.peek())
+	}
+	return
+}
+
+// params 参数列表, text 为解析后的参数及参数之间原有的分隔符
+func (p *tagParser) params() (params []string, text string, err error) {
+	var b strings.Builder
+	end := -1
+	for {
+		p.skipSpaces()
+		if p.eof() || p.peek() == ',' || p.peek() == '@' && p.isGroupSuffix() {
+			return params, b.String(), nil
+		}
+		if end >= 0 {
+			b.WriteString(string(p.s[end:p.pos]))
+		}
+
+		var param string
+		quoted := false
+		paramStart := p.pos
+		switch {
+		case p.peek() == '\'':
+			quoted = true
+			if param, err = p.quoted(); err != nil {
+				return nil, "", err
+			}
+		case p.hasPrefix(regexStart):
+			if param, err = p.regex(); err != nil {
+				return nil, "", err
+			}
+			quoted = true
+		default:
+			if param, err = p.bare(); err != nil {
+				return nil, "", err
+			}
+		}
+		params = append(params, param)
+		b.WriteString(param)
+		end = p.pos
+
+		if p.eof() {
+			return params, b.String(), nil
+		}
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t':
+		case c == ',':
+			// 逗号后为引号时仍是参数, 如 in='a b','c,d'
+			next := p.pos + 1
+			for next < len(p.s) && (p.s[next] == ' ' || p.s[next] == '\t') {
+				next++
+			}
+			if next < len(p.s) && p.s[next] == '\'' {
+				p.pos = next
+				continue
+			}
+			if !quoted {
+				p.cut, p.cutStart = p.pos, paramStart
+			}
+			return params, b.String(), nil
+		case c == '@':
+			return params, b.String(), nil
+		default:
+			if quoted {
+				return nil, "", p.errorf(p.pos, "参数后应为空格, 逗号或 @, 不是 %q", c)
+			}
+		}
+	}
+}
+
+// quoted 单引号参数
+func (p *tagParser) quoted() (string, error) {
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		switch {
+		case c == '\\' && p.pos+1 < len(p.s) && (p.s[p.pos+1] == '\'' || p.s[p.pos+1] == '\\'):
+			b.WriteRune(p.s[p.pos+1])
+			p.pos += 2
+		case c == '\'':
+			p.pos++
+			return b.String(), nil
+		default:
+			b.WriteRune(c)
+			p.pos++
+		}
+	}
+	return "", p.errorf(start, "引号未闭合")
+}
+
+const (
+	regexStart = "(/"
+)
+
+// regex 旧写法 (/.../), 以其后为逗号, @ 或结尾的 /) 结束
+func (p *tagParser) regex() (string, error) {
+	start := p.pos
+	for i := p.pos + len(regexStart); i+len(RegexTagEnd) <= len(p.s); i++ {
+		if string(p.s[i:i+len(RegexTagEnd)]) != RegexTagEnd {
+			continue
+		}
+		j := i + len(RegexTagEnd)
+		for j < len(p.s) && (p.s[j] == ' ' || p.s[j] == '\t') {
+			j++
+		}
+		if j == len(p.s) || p.s[j] == ',' || p.s[j] == '@' {
+			p.pos = i + len(RegexTagEnd)
+			return string(p.s[start+len(regexStart) : i]), nil
+		}
+	}
+	return "", p.errorf(start, "正则表达式缺少结尾的 %s", RegexTagEnd)
+}
+
+// bare 不带引号的参数
+func (p *tagParser) bare() (string, error) {
+	start := p.pos
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		switch {
+		case c == ' ' || c == '\t' || c == ',':
+			return b.String(), nil
+		case c == '@' && p.isGroupSuffix():
+			// 紧跟参数的 @ 也可能是参数的一部分, 如 default=me@corp, 只有数字之后的为分组, 如 gte=6@create
+			if p.pos > start {
+				if _, err := strconv.ParseFloat(b.String(), 64); err != nil {
+					end := p.nextComma(p.pos)
+					param := b.String() + strings.TrimSpace(string(p.s[p.pos:end]))
+					return "", p.errorf(p.pos, "参数 %s 中的 @ 有歧义, 作为参数请使用引号 %s 或 \\@, 作为分组请在 @ 前加空格",
+						string(p.s[start:end]), joinParams([]string{param}))
+				}
+			}
+			return b.String(), nil
+		case c == '\\' && p.pos+1 < len(p.s) && strings.ContainsRune(`\,' @`, p.s[p.pos+1]):
+			b.WriteRune(p.s[p.pos+1])
+			p.pos += 2
+		default:
+			b.WriteRune(c)
+			p.pos++
+		}
+	}
+	return b.String(), nil
+}
+
+// cutError 上一个参数在位置 cut 的逗号处结束, 之后的规则解析失败, 参数中可能包含逗号
+func (p *tagParser) cutError(cut int) *TagError {
+	end := p.nextComma(cut + 1)
+	return p.errorf(cut, "参数 %s 在逗号处结束, 之后的 %s 不是规则, 参数中含逗号时请使用引号, 如 %s",
+		string(p.s[p.cutStart:cut]), string(p.s[cut+1:end]), p.cutQuoted(cut))
+}
+
+// cutQuoted 在位置 cut 的逗号处结束的参数与之后到下一个逗号的内容加引号
+func (p *tagParser) cutQuoted(cut int) string {
+	return joinParams([]string{string(p.s[p.cutStart:p.nextComma(cut+1)])})
+}
+
+// nextComma 从 i 开始的第一个逗号的位置, 没有时为结尾
+func (p *tagParser) nextComma(i int) int {
+	for i < len(p.s) && p.s[i] != ',' {
+		i++
+	}
+	return i
+}
+
+// groups 规则分组, 如 @create|update
+func (p *tagParser) groups() (groups []string, err error) {
+	start := p.pos
+	p.pos++
+	for !p.eof() && p.peek() != ',' {
+		p.pos++
+	}
+	s := strings.TrimSpace(string(p.s[start+1 : p.pos]))
+	for _, r := range s {
+		if !isGroupRune(r) {
+			return nil, p.errorf(start, "分组名 %s 无效", s)
+		}
+	}
+	if groups = splitGroups(s, groupOr); len(groups) == 0 {
+		return nil, p.errorf(start, "缺少分组名")
+	}
+	return
+}
+
+// isGroupSuffix 当前的 @ 之后到逗号或结尾是否都是分组名, 如 in=a@b.com 中的 @ 不是分组
+func (p *tagParser) isGroupSuffix() bool {
+	i := p.pos + 1
+	for i < len(p.s) && p.s[i] != ',' {
+		if !isGroupRune(p.s[i]) && p.s[i] != ' ' {
+			return false
+		}
+		i++
+	}
+	return strings.TrimSpace(string(p.s[p.pos+1:i])) != ""
+}
+
+func (p *tagParser) hasPrefix(s string) bool {
+	return strings.HasPrefix(string(p.s[p.pos:]), s)
+}
+
+func (p *tagParser) skipSpaces() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+func (p *tagParser) peek() rune {
+	return p.s[p.pos]
+}
+
+func (p *tagParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *tagParser) errorf(pos int, format string, a ...interface{}) *TagError {
+	return &TagError{Tag: p.tag, Column: pos + 1, Msg: fmt.Sprintf(format, a...)}
+}
+
+func isNameRune(r rune) bool {
+	return r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+func isGroupRune(r rune) bool {
+	return isNameRune(r) || r == '-' || r == '|'
+}
+
+// listRules 参数为列表的规则, 其它规则最多一个参数
+var listRules = map[string]bool{"in": true, "sin": true}
+
+// splitParams 解析参数列表, 如 1 2 3, 'a b','c,d'
+func splitParams(s string) []string {
+	p := &tagParser{tag: s, s: []rune(s)}
+	params, _, _ := p.params()
+	return params
+}
+
+// joinParams 参数列表转为可以由 splitParams 解析的形式, 含空格, 逗号, 引号或反斜杠的参数加引号
+func joinParams(params []string) string {
+	s := make([]string, len(params))
+	for i, param := range params {
+		if param == "" || strings.ContainsAny(param, " \t,'\\@") || strings.HasPrefix(param, regexStart) {
+			param = "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(param) + "'"
+		}
+		s[i] = param
+	}
+	return strings.Join(s, " ")
+}
+
+// quoteParams 参数列表用于错误信息, 多个参数时含空格或逗号的参数加引号
+func quoteParams(params []string) string {
+	if len(params) == 1 {
+		return params[0]
+	}
+	s := make([]string, len(params))
+	for i, param := range params {
+		if param == "" || strings.ContainsAny(param, " ,'") {
+			param = "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(param) + "'"
+		}
+		s[i] = param
+	}
+	return strings.Join(s, " ")
+}
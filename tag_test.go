@@ -0,0 +1,130 @@
+package gvalid
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 16:40
+ * @Desc:
+ */
+
+func TestParseTag(t *testing.T) {
+	Convey("test parse tag", t, func() {
+		cases := []struct {
+			tag string
+			vfs []ValidFunc
+		}{
+			{"required, gte=1 ,lte=10", []ValidFunc{{Name: "RuleRequired", Params: ""}, {Name: "RuleGte", Params: "1", Values: []string{"1"}}, {Name: "RuleLte", Params: "10", Values: []string{"10"}}}},
+			{"in=1 2  3", []ValidFunc{{Name: "RuleIn", Params: "1 2 3", Values: []string{"1", "2", "3"}}}},
+			{"in='a b','c,d' e", []ValidFunc{{Name: "RuleIn", Params: "'a b' 'c,d' e", Values: []string{"a b", "c,d", "e"}}}},
+			{`in='it\'s','a\\b','\d'`, []ValidFunc{{Name: "RuleIn", Params: `'it\'s' 'a\\b' '\\d'`, Values: []string{"it's", `a\b`, `\d`}}}},
+			{`in=a\,b c\ d`, []ValidFunc{{Name: "RuleIn", Params: "'a,b' 'c d'", Values: []string{"a,b", "c d"}}}},
+			{"in='a','b'@create,required", []ValidFunc{{Name: "RuleIn", Params: "a b", Values: []string{"a", "b"}, Groups: []string{"create"}}, {Name: "RuleRequired", Params: ""}}},
+			{"date='Jan 2, 2006'", []ValidFunc{{Name: "RuleDate", Params: "Jan 2, 2006", Values: []string{"Jan 2, 2006"}}}},
+			{"date=2006-01-02 15:04:05", []ValidFunc{{Name: "RuleDate", Params: "2006-01-02 15:04:05", Values: []string{"2006-01-02 15:04:05"}}}},
+			{"date=2006-01-02  15:04", []ValidFunc{{Name: "RuleDate", Params: "2006-01-02  15:04", Values: []string{"2006-01-02  15:04"}}}},
+			{"default=a=b", []ValidFunc{{Name: "RuleDefault", Params: "a=b", Values: []string{"a=b"}}}},
+			{"regex=(/^a,b$/),regex=(/^\\d+$/)@admin", []ValidFunc{{Name: "RuleRegex", Params: "^a,b$", Values: []string{"^a,b$"}}, {Name: "RuleRegex", Params: `^\d+$`, Values: []string{`^\d+$`}, Groups: []string{"admin"}}}},
+			{"regex='^[a-z]{1,3}$'", []ValidFunc{{Name: "RuleRegex", Params: "^[a-z]{1,3}$", Values: []string{"^[a-z]{1,3}$"}}}},
+			{"in=a@b.com", []ValidFunc{{Name: "RuleIn", Params: "'a@b.com'", Values: []string{"a@b.com"}}}},
+			{"in=1 2@create", []ValidFunc{{Name: "RuleIn", Params: "1 2", Values: []string{"1", "2"}, Groups: []string{"create"}}}},
+			{"default=me @corp", []ValidFunc{{Name: "RuleDefault", Params: "me", Values: []string{"me"}, Groups: []string{"corp"}}}},
+			{`default=me\@corp`, []ValidFunc{{Name: "RuleDefault", Params: "me@corp", Values: []string{"me@corp"}}}},
+		}
+		for _, c := range cases {
+			vfs, err := parseTag(c.tag)
+			So(err, ShouldBeNil)
+			So(vfs, ShouldResemble, c.vfs)
+		}
+	})
+
+	Convey("test parse tag error", t, func() {
+		cases := []struct {
+			tag    string
+			column int
+		}{
+			{"in='a b", 4},
+			{"required,=1", 10},
+			{"1required", 1},
+			{"in='a'b", 7},
+			{"regex=(/^a", 7},
+			{"regex='('", 7},
+			{"required@", 9},
+			{"required foo", 10},
+			{"default=me@corp", 11},
+			{"regex=^[a-z]{1,3}$", 15},
+		}
+		for _, c := range cases {
+			_, err := parseTag(c.tag)
+			So(err, ShouldNotBeNil)
+			So(errors.Is(err, InvalidExpr), ShouldBeTrue)
+			So(err.(*TagError).Column, ShouldEqual, c.column)
+		}
+	})
+
+	Convey("test parse tag error message", t, func() {
+		_, err := ParseTag("default=me@corp")
+		So(err.Error(), ShouldEqual, `valid:"default=me@corp" 第 11 列: 参数 me@corp 中的 @ 有歧义, 作为参数请使用引号 'me@corp' 或 \@, 作为分组请在 @ 前加空格`)
+
+		// 参数中的逗号结束了参数, 提示加引号
+		_, err = ParseTag("regex=^[a-z]{1,3}$,required")
+		So(err.Error(), ShouldEqual, `valid:"regex=^[a-z]{1,3}$,required" 第 15 列: 参数 ^[a-z]{1 在逗号处结束, 之后的 3}$ 不是规则, 参数中含逗号时请使用引号, 如 '^[a-z]{1,3}$'`)
+		_, err = ParseTag("regex=^(a,b)$")
+		So(err.Error(), ShouldEndWith, "参数中含逗号时请使用引号, 如 '^(a,b)$'")
+	})
+}
+
+func TestTagError(t *testing.T) {
+	Convey("test tag error", t, func() {
+		type Goods struct {
+			Name string `valid:"required,in='a b"`
+		}
+		v := &Validation{}
+		_, err := v.Valid(&Goods{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, `Goods.Name: valid:"required,in='a b" 第 13 列: 引号未闭合`)
+	})
+
+	Convey("test quoted params", t, func() {
+		type Goods struct {
+			Color []string `valid:"sin='light blue','red, dark'" name:"颜色"`
+			Size  string   `valid:"in='X L' M" name:"尺码"`
+			Code  string   `valid:"regex=(/^[A-Z]/),regex=(/\\d$/)" name:"编号"`
+		}
+		v := &Validation{}
+		b, err := v.Valid(&Goods{Color: []string{"red, dark"}, Size: "X L", Code: "A1"})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+
+		v = &Validation{}
+		_, _ = v.Valid(&Goods{Color: []string{"red"}, Size: "X", Code: "A"})
+		So(errorPaths(v), ShouldResemble, []string{"Color", "Size", "Code"})
+		So(v.Errors[0].Message, ShouldEqual, "必须是 'light blue' 'red, dark' 其中一个或多个")
+		So(v.Errors[2].Param, ShouldEqual, `\d$`)
+	})
+
+	Convey("test string params", t, func() {
+		type Goods struct {
+			At string `valid:"date=2006-01-02  15:04" name:"时间"`
+		}
+		v := &Validation{}
+		b, err := v.Valid(&Goods{At: "2024-01-02  10:30"})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+
+		// 直接调用验证函数时解析参数列表
+		f, _ := reflect.TypeOf(Goods{}).FieldByName("At")
+		v = &Validation{}
+		v.RuleIn(f, reflect.ValueOf("c,d"), "'a b','c,d'")
+		v.RuleIn(f, reflect.ValueOf("e"), "'a b' 'c,d'")
+		So(v.Errors, ShouldHaveLength, 1)
+		So(v.Errors[0].Message, ShouldEqual, "必须是 'a b' 'c,d' 其中一个")
+	})
+}
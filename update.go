@@ -0,0 +1,39 @@
+package gvalid
+
+import (
+	"fmt"
+	"reflect"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 09:30
+ * @Desc: 更新验证, 比较新旧值
+ */
+
+// ValidateUpdate 验证 newObj, 同时按 immutable, transitions 规则与 old 逐字段比较
+// old, newObj 必须是相同类型的结构体或结构体指针, dive 的嵌套结构体按相同路径 (slice 为相同下标) 比较
+func (valid *Validation) ValidateUpdate(old, newObj interface{}) (b bool, err error) {
+	tOld, tNew := reflect.TypeOf(old), reflect.TypeOf(newObj)
+	if tOld == nil || tNew == nil || !isStructOrStructPtr(tOld) || !isStructOrStructPtr(tNew) {
+		err = fmt.Errorf("%v, %v 必须是 结构体 或者 结构体指针", old, newObj)
+		return
+	}
+	if indirectType(tOld) != indirectType(tNew) {
+		err = fmt.Errorf("%v 与 %v 类型不一致", tOld, tNew)
+		return
+	}
+
+	valid.old = reflect.ValueOf(old)
+	defer func() { valid.old = reflect.Value{} }()
+	return valid.Valid(newObj)
+}
+
+// indirectType 取指针指向的类型
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
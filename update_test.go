@@ -0,0 +1,86 @@
+package gvalid
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/20 10:05
+ * @Desc:
+ */
+
+type updateSku struct {
+	SkuCode string `valid:"required,immutable" name:"SKU编号"`
+	Stock   int    `valid:"gte=0" name:"库存"`
+}
+
+type updateGoods struct {
+	Code   string       `valid:"required,immutable" name:"商品编号"`
+	Status int          `valid:"required,transitions=1>2 2>3 2>1" name:"状态"`
+	State  string       `valid:"transitions=DRAFT>ONLINE ONLINE>OFFLINE" name:"上架状态"`
+	Skus   []*updateSku `valid:"dive" name:"SKU"`
+}
+
+func TestValidateUpdate(t *testing.T) {
+	old := &updateGoods{Code: "A001", Status: 1, State: "DRAFT", Skus: []*updateSku{{SkuCode: "S1"}, {SkuCode: "S2"}}}
+
+	Convey("test validate update", t, func() {
+		v := &Validation{}
+		b, err := v.ValidateUpdate(old, &updateGoods{Code: "A001", Status: 2, State: "ONLINE",
+			Skus: []*updateSku{{SkuCode: "S1"}, {SkuCode: "S2"}, {SkuCode: "S3"}}})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+
+		v = &Validation{}
+		b, err = v.ValidateUpdate(old, &updateGoods{Code: "A002", Status: 3, State: "OFFLINE",
+			Skus: []*updateSku{{SkuCode: "S1"}, {SkuCode: "S9", Stock: -1}}})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(errorPaths(v), ShouldResemble, []string{"Code", "Status", "State", "Skus[1].SkuCode", "Skus[1].Stock"})
+		So(v.ErrorsMap["Code"][0].Rule, ShouldEqual, "immutable")
+		So(v.ErrorsMap["Status"][0].String(), ShouldEqual, "状态 不允许从 1 变更为 3")
+	})
+
+	Convey("test zero values", t, func() {
+		type level struct {
+			Code  *string `valid:"immutable" name:"编码"`
+			Small int16   `valid:"transitions=1>2 2>0" name:"等级"`
+			Flag  uint8   `valid:"transitions=1>2" name:"标记"`
+		}
+		code := "A001"
+		v := &Validation{}
+		b, err := v.ValidateUpdate(&level{Code: &code, Small: 1, Flag: 1}, &level{Small: 0, Flag: 2})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeFalse)
+		So(errorPaths(v), ShouldResemble, []string{"Code", "Small"})
+		So(v.ErrorsMap["Small"][0].String(), ShouldEqual, "等级 不允许从 1 变更为 0")
+
+		// 旧值为零值时允许设置
+		v = &Validation{}
+		b, err = v.ValidateUpdate(&level{}, &level{Code: &code, Small: 2, Flag: 2})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+
+		v = &Validation{}
+		b, err = v.ValidateUpdate(&level{Small: 2, Flag: 1}, &level{Small: 0, Flag: 1})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+	})
+
+	Convey("test valid without old", t, func() {
+		v := &Validation{}
+		b, err := v.Valid(&updateGoods{Code: "A002", Status: 3})
+		So(err, ShouldBeNil)
+		So(b, ShouldBeTrue)
+	})
+
+	Convey("test type mismatch", t, func() {
+		v := &Validation{}
+		_, err := v.ValidateUpdate(&updateSku{}, &updateGoods{})
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -3,7 +3,6 @@ package gvalid
 import (
 	"errors"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
 )
@@ -27,78 +26,110 @@ func isStructOrStructPtr(t reflect.Type) bool {
 	return t.Kind() == reflect.Struct || t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
 }
 
-// matchValidFunc 匹配验证 func
-func matchValidFunc(f reflect.StructField) (vfs []ValidFunc, err error) {
-	tag := f.Tag.Get(defaultTagName)
-	if f.Anonymous && isStructOrStructPtr(f.Type) && tag == "" {
-		vfs = []ValidFunc{
-			// 这边不灵活
-			{Name: "RuleDive", Params: ""},
+// indirect 取指针指向的值, nil 指针返回无效的 reflect.Value
+func indirect(vOf reflect.Value) reflect.Value {
+	for vOf.IsValid() && vOf.Kind() == reflect.Ptr {
+		if vOf.IsNil() {
+			return reflect.Value{}
 		}
-		return
-	}
-	if tag == "" || tag == skipValidationTag {
-		return
+		vOf = vOf.Elem()
 	}
+	return vOf
+}
 
-	if vfs, tag, err = parseRegexFunc(tag, f.Name); err != nil {
+// matchValidFunc 匹配验证 func, ov 为使用的覆盖规则, tag 语法错误时返回 *TagError
+func matchValidFunc(t reflect.Type, f reflect.StructField, ov *ruleOverrides) (vfs []ValidFunc, err error) {
+	rules, err := overriddenRules(t, f, ov)
+	if err != nil {
 		return
 	}
-
-	for _, rule := range strings.Split(tag, tagSep) {
-		if rule == "" {
-			continue
-		}
-		var vf ValidFunc
-		if vf, err = parseFunc(rule); err != nil {
-			return
-		}
-		vfs = append(vfs, vf)
-	}
-	return
+	return rules.validFuncs(), nil
 }
 
 // ValidFunc 验证函数
 type ValidFunc struct {
-	Name   string
+	Name string
+	// Params 传给验证函数的参数 (string), 如 date='2006-01-02  15:04' 为 2006-01-02  15:04
+	// in, sin 为可以再次解析的参数列表, 如 in='a b','c,d' 为 'a b' 'c,d'
 	Params interface{}
+	// Values 解析后的参数, 如 in='a b','c,d' 为 [a b, c,d]
+	Values []string
+	// Groups 规则所属分组, 为空时所有分组都验证
+	Groups []string
+}
+
+// Param 空格拼接后的参数
+func (vf ValidFunc) Param() string {
+	return strings.Join(vf.Values, " ")
 }
 
 var (
 	InvalidExpr = errors.New("invalid expr")
 )
 
-// parseRegexFunc 匹配正则 func
-func parseRegexFunc(tag, key string) (vfs []ValidFunc, str string, err error) {
-	tag = strings.TrimSpace(tag)
-	index := strings.Index(tag, RegexTagStart)
-	if index == -1 {
-		str = tag
-		return
+// splitGroups 拆分分组名
+func splitGroups(s, sep string) (groups []string) {
+	for _, g := range strings.Split(s, sep) {
+		if g = strings.TrimSpace(g); g != "" {
+			groups = append(groups, g)
+		}
 	}
-	end := strings.LastIndex(tag, RegexTagEnd)
-	if end < index {
-		err = InvalidExpr
-		return
+	return
+}
+
+// ruleName 验证 func 名称转为 tag 中的规则名, 如 RuleIdCard => idCard
+func ruleName(funcName string) string {
+	return toLowerCamel(strings.TrimPrefix(funcName, validFuncPrefix))
+}
+
+// joinPath 拼接字段路径
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
 	}
-	reg, err := regexp.Compile(tag[index+len(RegexTagStart) : end])
-	if err != nil {
-		return
+	if name == "" {
+		return prefix
 	}
-	vfs = []ValidFunc{{validFuncPrefix + RegexFunc, reg.String()}}
-	str = strings.TrimSpace(tag[:index]) + strings.TrimSpace(tag[end+len(RegexTagEnd):])
-	return
+	return prefix + "." + name
 }
 
-// parseFunc 匹配要验证的 func
-func parseFunc(rule string) (v ValidFunc, err error) {
-	ruleSlice := strings.Split(strings.TrimSpace(rule), tagKeySep)
-	var params string
-	if len(ruleSlice) == 2 {
-		params = ruleSlice[1]
+// fieldPath 字段路径, 匿名嵌入的结构体不增加路径
+func fieldPath(prefix string, f reflect.StructField) string {
+	if f.Anonymous {
+		return prefix
 	}
-	v = ValidFunc{validFuncPrefix + toUpperCamel(ruleSlice[0]), params}
-	return
+	return joinPath(prefix, f.Name)
+}
+
+// trimIndex 去除路径中的下标, 如 Address[0].City => Address.City
+func trimIndex(path string) string {
+	if strings.IndexByte(path, '[') == -1 {
+		return path
+	}
+	var b strings.Builder
+	skip := false
+	for _, r := range path {
+		switch {
+		case r == '[':
+			skip = true
+		case r == ']':
+			skip = false
+		case !skip:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// pathHasPrefix path 是否是 prefix 或其子路径, 如 Gallery.ImgUrl, List[0] 均属于 Gallery, List
+func pathHasPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	if len(path) == len(prefix) || prefix == "" {
+		return true
+	}
+	return path[len(prefix)] == '.' || path[len(prefix)] == '['
 }
 
 // toUpperCamel 首字母大写
@@ -123,6 +154,31 @@ func toLowerCamel(s string) string {
 	return s
 }
 
+// ValidEmail 验证邮箱, 同 email 规则
+func ValidEmail(val string) bool {
+	return emailPattern.MatchString(val)
+}
+
+// ValidMobile 验证手机号码, 同 mobile 规则
+func ValidMobile(val string) bool {
+	return mobilePattern.MatchString(val)
+}
+
+// ValidBase64 验证 base64, 同 base64 规则
+func ValidBase64(val string) bool {
+	return base64Pattern.MatchString(val)
+}
+
+// ValidIp 验证 ip, 同 ip 规则
+func ValidIp(val string) bool {
+	return ipPattern.MatchString(val)
+}
+
+// ValidUrl 验证 url, 同 url 规则
+func ValidUrl(val string) bool {
+	return urlPattern.MatchString(val)
+}
+
 // ValidIdCardCode 验证身份证号码
 func ValidIdCardCode(val string) bool {
 	if len(val) != 18 {
@@ -27,6 +27,101 @@ var (
 	validFuncMap = make(Funcs)
 )
 
+// paramArity 规则的参数个数
+type paramArity int
+
+const (
+	// optionalParams 参数可有可无
+	optionalParams paramArity = iota
+	// noParams 不需要参数
+	noParams
+	// needParams 至少一个参数
+	needParams
+)
+
+// ruleSpec 规则的参数及支持的字段类型, 验证时及 CheckRule 共用
+type ruleSpec struct {
+	params paramArity
+	// kinds, types 支持的字段类型, 都为空时不限
+	kinds []reflect.Kind
+	types []string
+	// check 额外的检查, t 为 nil 时只检查参数
+	check func(r Rule, t *FieldType) string
+}
+
+var (
+	intKinds    = []reflect.Kind{reflect.Int8, reflect.Int32, reflect.Int, reflect.Int64}
+	floatKinds  = []reflect.Kind{reflect.Float32, reflect.Float64}
+	lenKinds    = []reflect.Kind{reflect.String, reflect.Slice, reflect.Map, reflect.Array}
+	sliceTypes  = []string{"[]int", "[]int64", "[]string"}
+	stringKinds = []reflect.Kind{reflect.String}
+	// compareKinds gt, gte, lt, lte 支持的类型, 字符串, slice, map, array 比较长度
+	compareKinds = append(append(append([]reflect.Kind{}, intKinds...), floatKinds...), lenKinds...)
+	// transitionKinds transitions 支持的类型
+	transitionKinds = []reflect.Kind{reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64, reflect.String}
+
+	// ruleSpecs 规则名 => ruleSpec, 新增的验证函数须在此说明参数及支持的字段类型
+	ruleSpecs = map[string]ruleSpec{
+		"required":    {params: noParams},
+		"empty":       {params: noParams},
+		"immutable":   {params: noParams},
+		"email":       {params: noParams, kinds: stringKinds},
+		"mobile":      {params: noParams, kinds: stringKinds},
+		"base64":      {params: noParams, kinds: stringKinds},
+		"ip":          {params: noParams, kinds: stringKinds},
+		"url":         {params: noParams, kinds: stringKinds},
+		"idCard":      {params: noParams, kinds: stringKinds},
+		"numeric":     {params: noParams, kinds: stringKinds},
+		"trimSpace":   {params: noParams, kinds: stringKinds},
+		"gt":          {params: needParams, kinds: compareKinds, check: numberParams},
+		"gte":         {params: needParams, kinds: compareKinds, check: numberParams},
+		"lt":          {params: needParams, kinds: compareKinds, check: numberParams},
+		"lte":         {params: needParams, kinds: compareKinds, check: numberParams},
+		"len":         {params: needParams, kinds: lenKinds, check: func(r Rule, _ *FieldType) string { return intParams(r) }},
+		"date":        {params: needParams, kinds: stringKinds},
+		"regex":       {params: needParams, kinds: stringKinds},
+		"strGte":      {params: needParams, kinds: stringKinds},
+		"strLte":      {params: needParams, kinds: stringKinds},
+		"in":          {params: needParams, kinds: append(append([]reflect.Kind{}, intKinds...), reflect.String), check: inParams},
+		"default":     {params: needParams, kinds: append(append([]reflect.Kind{}, intKinds...), reflect.String), check: inParams},
+		"sin":         {params: needParams, types: sliceTypes, check: sinParams},
+		"distinct":    {params: noParams, types: sliceTypes},
+		"transitions": {params: needParams, kinds: transitionKinds, check: transitionParams},
+		"enum":        {check: enumRuleParams},
+		"dive":        {params: noParams, check: diveKinds},
+	}
+)
+
+// kindError 字段类型 kind, typ 不支持时的错误信息
+func (spec ruleSpec) kindError(rule string, kind reflect.Kind, typ string) string {
+	if len(spec.kinds) == 0 && len(spec.types) == 0 {
+		return ""
+	}
+	if kindOf(kind, spec.kinds) {
+		return ""
+	}
+	for _, s := range spec.types {
+		if s == typ {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s 不支持 %s 类型", rule, typ)
+}
+
+// kindAllowed 验证前按 ruleSpec 检查字段值的类型, 不支持时设置 Error, 同验证函数一样不检查零值
+func (valid *Validation) kindAllowed(tOf reflect.StructField, vOf reflect.Value) bool {
+	v := indirect(vOf)
+	if !v.IsValid() || v.IsZero() {
+		return true
+	}
+	if msg := ruleSpecs[valid.rule].kindError(valid.rule, v.Kind(), v.Type().String()); msg != "" {
+		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), fmt.Sprintf(ValidateMethodNotAllowSth, valid.rule, v.Type()))
+		return false
+	}
+	return true
+}
+
 var (
 	loc, _ = time.LoadLocation(DefaultLocal)
 )
@@ -36,6 +131,11 @@ func SetLocal(local string) (err error) {
 	return
 }
 
+// Location date 规则使用的时区, 通过 SetLocal 设置
+func Location() *time.Location {
+	return loc
+}
+
 func init() {
 	t := reflect.TypeOf(&Validation{})
 	for i := 0; i < t.NumMethod(); i++ {
@@ -54,6 +154,14 @@ func (valid *Validation) RuleRequired(tOf reflect.StructField, vOf reflect.Value
 	return
 }
 
+// RuleEmpty 必须为空, 如创建时 Id 必须为空
+func (valid *Validation) RuleEmpty(tOf reflect.StructField, vOf reflect.Value, _ string) {
+	if !vOf.IsZero() {
+		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), ValidateValMustEmpty)
+	}
+	return
+}
+
 // RuleGt 大于
 // 支持: int8, int32, int, int64,
 // float32, float64,
@@ -364,7 +472,45 @@ func (valid *Validation) RuleDate(tOf reflect.StructField, vOf reflect.Value, fo
 	return
 }
 
-// RuleIn in
+// RuleStrGte 字符串按字典序大于等于, 如日期 strGte=2024-01-01, 字符串长度使用 gte
+// 支持: string
+func (valid *Validation) RuleStrGte(tOf reflect.StructField, vOf reflect.Value, min string) {
+	if vOf.IsZero() {
+		return
+	}
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
+	}
+	if vOf.String() < min {
+		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), fmt.Sprintf(ValidateValNotStrGte, min))
+	}
+	return
+}
+
+// RuleStrLte 字符串按字典序小于等于, 如 strLte=2024-12-31, 字符串长度使用 lte
+// 支持: string
+func (valid *Validation) RuleStrLte(tOf reflect.StructField, vOf reflect.Value, max string) {
+	if vOf.IsZero() {
+		return
+	}
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
+	}
+	if vOf.String() > max {
+		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), fmt.Sprintf(ValidateValNotStrLte, max))
+	}
+	return
+}
+
+// paramValues in, sin 的参数列表, 验证时使用解析 tag 的结果, 直接调用验证函数时解析 s
+func (valid *Validation) paramValues(s string) []string {
+	if valid.values != nil {
+		return valid.values
+	}
+	return splitParams(s)
+}
+
+// RuleIn in, 如 in=1 2 3, in='a b','c,d'
 // 支持: int8, int32, int, int64,
 // string
 func (valid *Validation) RuleIn(tOf reflect.StructField, vOf reflect.Value, size string) {
@@ -375,11 +521,12 @@ func (valid *Validation) RuleIn(tOf reflect.StructField, vOf reflect.Value, size
 	if vOf.Kind() == reflect.Ptr {
 		vOf = vOf.Elem()
 	}
+	values := valid.paramValues(size)
 
 	name, tag := tOf.Name, tOf.Tag.Get(defaultNameTag)
 	switch vOf.Kind() {
 	case reflect.Int8, reflect.Int32, reflect.Int, reflect.Int64:
-		for _, v := range strings.Split(size, " ") {
+		for _, v := range values {
 			s, err := strconv.Atoi(v)
 			if err != nil {
 				valid.SetError(name, tag, ValidateValTypeErr)
@@ -389,14 +536,14 @@ func (valid *Validation) RuleIn(tOf reflect.StructField, vOf reflect.Value, size
 				return
 			}
 		}
-		valid.SetError(name, tag, fmt.Sprintf(ValidateValNotExists, size))
+		valid.SetError(name, tag, fmt.Sprintf(ValidateValNotExists, quoteParams(values)))
 	case reflect.String:
-		for _, v := range strings.Split(size, " ") {
+		for _, v := range values {
 			if v == vOf.String() {
 				return
 			}
 		}
-		valid.SetError(name, tag, fmt.Sprintf(ValidateValNotExists, size))
+		valid.SetError(name, tag, fmt.Sprintf(ValidateValNotExists, quoteParams(values)))
 	default:
 		valid.SetError(name, tag, fmt.Sprintf(ValidateMethodNotAllowSth, "in", vOf.String()))
 	}
@@ -415,12 +562,13 @@ func (valid *Validation) RuleSin(tOf reflect.StructField, vOf reflect.Value, siz
 	if vOf.Kind() == reflect.Ptr {
 		vOf = vOf.Elem()
 	}
+	values := valid.paramValues(size)
 
 	name, tag := tOf.Name, tOf.Tag.Get(defaultNameTag)
-	switch tOf.Type.String() {
+	switch vOf.Type().String() {
 	case "[]int":
 		i := map[int]struct{}{}
-		for _, v := range strings.Split(size, " ") {
+		for _, v := range values {
 			val, err := strconv.Atoi(v)
 			if err != nil {
 				valid.SetError(name, tag, ValidateValTypeErr)
@@ -430,13 +578,13 @@ func (valid *Validation) RuleSin(tOf reflect.StructField, vOf reflect.Value, siz
 		}
 		for _, v := range vOf.Interface().([]int) {
 			if _, ok := i[v]; !ok {
-				valid.SetError(name, tag, fmt.Sprintf(ValidateValNotExistsSlice, size))
+				valid.SetError(name, tag, fmt.Sprintf(ValidateValNotExistsSlice, quoteParams(values)))
 				return
 			}
 		}
 	case "[]int64":
 		i := map[int]struct{}{}
-		for _, v := range strings.Split(size, " ") {
+		for _, v := range values {
 			val, err := strconv.Atoi(v)
 			if err != nil {
 				valid.SetError(name, tag, ValidateValTypeErr)
@@ -446,18 +594,18 @@ func (valid *Validation) RuleSin(tOf reflect.StructField, vOf reflect.Value, siz
 		}
 		for _, v := range vOf.Interface().([]int64) {
 			if _, ok := i[int(v)]; !ok {
-				valid.SetError(name, tag, fmt.Sprintf(ValidateValNotExistsSlice, size))
+				valid.SetError(name, tag, fmt.Sprintf(ValidateValNotExistsSlice, quoteParams(values)))
 				return
 			}
 		}
 	case "[]string":
 		i := map[string]struct{}{}
-		for _, v := range strings.Split(size, " ") {
+		for _, v := range values {
 			i[v] = struct{}{}
 		}
 		for _, v := range vOf.Interface().([]string) {
 			if _, ok := i[v]; !ok {
-				valid.SetError(name, tag, fmt.Sprintf(ValidateValNotExistsSlice, size))
+				valid.SetError(name, tag, fmt.Sprintf(ValidateValNotExistsSlice, quoteParams(values)))
 				return
 			}
 		}
@@ -467,24 +615,49 @@ func (valid *Validation) RuleSin(tOf reflect.StructField, vOf reflect.Value, siz
 }
 
 // RuleDive 嵌套验证
-func (valid *Validation) RuleDive(tOf reflect.StructField, vOf reflect.Value, _ string) {
+// 嵌套结构体的字段路径为 Parent.Field, slice 为 Parent[i].Field, 匿名嵌入的结构体不增加路径
+// 返回嵌套验证过程中的错误, 如 ValidCustomCtx 返回的 error
+func (valid *Validation) RuleDive(tOf reflect.StructField, vOf reflect.Value, _ string) (err error) {
+	path, old := valid.path, valid.oldField
+	defer func() { valid.path, valid.oldField = path, old }()
 
+	valid.path = fieldPath(path, tOf)
+
+	// ValidateUpdate 时按相同下标对应旧值
 	if vOf.Type().Kind() == reflect.Slice {
 		l := vOf.Len()
 		// 仅支持 slice 类型的 struct
 		if l > 0 && !isStructOrStructPtr(vOf.Index(0).Type()) {
 			return
 		}
+		prefix := valid.path
 		for i := 0; i < l; i++ {
-			_, _ = valid.Valid(vOf.Index(i))
+			if vOf.Index(i).Kind() == reflect.Ptr && vOf.Index(i).IsNil() {
+				continue
+			}
+			valid.path = fmt.Sprintf("%s[%d]", prefix, i)
+			if old.IsValid() && old.Kind() == reflect.Slice && i < old.Len() {
+				valid.old = old.Index(i)
+			}
+			if _, err = valid.Valid(vOf.Index(i)); err != nil {
+				return
+			}
 		}
 	} else if isStruct(tOf.Type) {
-		_, _ = valid.Valid(vOf)
+		valid.old = old
+		if tOf.Anonymous {
+			valid.embeddedIn = valid.parent
+		}
+		_, err = valid.Valid(vOf)
 	} else if isStructPtr(tOf.Type) {
 		if vOf.IsZero() {
 			vOf.Set(reflect.New(tOf.Type.Elem()))
 		}
-		_, _ = valid.Valid(vOf)
+		valid.old = old
+		if tOf.Anonymous {
+			valid.embeddedIn = valid.parent
+		}
+		_, err = valid.Valid(vOf)
 	}
 
 	return
@@ -498,6 +671,9 @@ func (valid *Validation) RuleRegex(tOf reflect.StructField, vOf reflect.Value, p
 	if vOf.IsZero() {
 		return
 	}
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
+	}
 	if m, _ := regexp.MatchString(pattern, vOf.String()); !m {
 		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), ValidateValNotFormatErr)
 	}
@@ -509,6 +685,9 @@ func (valid *Validation) RuleEmail(tOf reflect.StructField, vOf reflect.Value, _
 	if vOf.IsZero() {
 		return
 	}
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
+	}
 	if b := emailPattern.MatchString(vOf.String()); !b {
 		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), ValidateValNotFormatErr)
 	}
@@ -520,6 +699,9 @@ func (valid *Validation) RuleMobile(tOf reflect.StructField, vOf reflect.Value,
 	if vOf.IsZero() {
 		return
 	}
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
+	}
 	if b := mobilePattern.MatchString(vOf.String()); !b {
 		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), ValidateValNotFormatErr)
 	}
@@ -531,6 +713,9 @@ func (valid *Validation) RuleBase64(tOf reflect.StructField, vOf reflect.Value,
 	if vOf.IsZero() {
 		return
 	}
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
+	}
 	if b := base64Pattern.MatchString(vOf.String()); !b {
 		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), ValidateValNotFormatErr)
 	}
@@ -542,6 +727,9 @@ func (valid *Validation) RuleIp(tOf reflect.StructField, vOf reflect.Value, _ st
 	if vOf.IsZero() {
 		return
 	}
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
+	}
 	if b := ipPattern.MatchString(vOf.String()); !b {
 		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), ValidateValNotFormatErr)
 	}
@@ -553,6 +741,9 @@ func (valid *Validation) RuleUrl(tOf reflect.StructField, vOf reflect.Value, _ s
 	if vOf.IsZero() {
 		return
 	}
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
+	}
 	if b := urlPattern.MatchString(vOf.String()); !b {
 		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), ValidateValNotFormatErr)
 	}
@@ -564,6 +755,9 @@ func (valid *Validation) RuleIdCard(tOf reflect.StructField, vOf reflect.Value,
 	if vOf.IsZero() {
 		return
 	}
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
+	}
 	if b := ValidIdCardCode(vOf.String()); !b {
 		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), ValidateValNotFormatErr)
 	}
@@ -575,6 +769,9 @@ func (valid *Validation) RuleNumeric(tOf reflect.StructField, vOf reflect.Value,
 	if vOf.IsZero() {
 		return
 	}
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
+	}
 	for _, v := range vOf.String() {
 		if v < 48 || v > 57 {
 			valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), ValidateValNotNumericErr)
@@ -616,8 +813,8 @@ func (valid *Validation) RuleDistinct(tOf reflect.StructField, vOf reflect.Value
 	if vOf.IsZero() {
 		return
 	}
-	if vOf.Kind() != reflect.Slice {
-		valid.SetError(tOf.Name, "distinct", ValidateValTypeErr)
+	if vOf.Kind() == reflect.Ptr {
+		vOf = vOf.Elem()
 	}
 	name, tag := tOf.Name, tOf.Tag.Get(defaultNameTag)
 	switch vOf.Type().String() {
@@ -625,7 +822,7 @@ func (valid *Validation) RuleDistinct(tOf reflect.StructField, vOf reflect.Value
 		i := map[int]struct{}{}
 		for _, v := range vOf.Interface().([]int) {
 			if _, ok := i[v]; ok {
-				valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), fmt.Sprintf(ValidateValMustDistinct, vOf.Interface()))
+				valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), fmt.Sprintf(ValidateValMustDistinct, valid.display(vOf.Interface())))
 				return
 			}
 			i[v] = struct{}{}
@@ -634,7 +831,7 @@ func (valid *Validation) RuleDistinct(tOf reflect.StructField, vOf reflect.Value
 		i := map[int64]struct{}{}
 		for _, v := range vOf.Interface().([]int64) {
 			if _, ok := i[v]; ok {
-				valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), fmt.Sprintf(ValidateValMustDistinct, vOf.Interface()))
+				valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), fmt.Sprintf(ValidateValMustDistinct, valid.display(vOf.Interface())))
 				return
 			}
 			i[v] = struct{}{}
@@ -643,14 +840,14 @@ func (valid *Validation) RuleDistinct(tOf reflect.StructField, vOf reflect.Value
 		i := map[string]struct{}{}
 		for _, v := range vOf.Interface().([]string) {
 			if _, ok := i[v]; ok {
-				valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), fmt.Sprintf(ValidateValMustDistinct, vOf.Interface()))
+				valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), fmt.Sprintf(ValidateValMustDistinct, valid.display(vOf.Interface())))
 				return
 			}
 			i[v] = struct{}{}
 		}
 
 	default:
-		valid.SetError(name, tag, fmt.Sprintf(ValidateMethodNotAllowSth, "distinct", vOf.Interface()))
+		valid.SetError(name, tag, fmt.Sprintf(ValidateMethodNotAllowSth, "distinct", vOf.Type().String()))
 	}
 	return
 }
@@ -674,3 +871,76 @@ func (valid *Validation) RuleTrimSpace(tOf reflect.StructField, vOf reflect.Valu
 	}
 	return
 }
+
+// RuleImmutable 不允许修改, 仅 ValidateUpdate 时验证
+// 旧值为空或零值时允许设置, 旧值不为空时新值不能与旧值不同, 包括清空
+func (valid *Validation) RuleImmutable(tOf reflect.StructField, vOf reflect.Value, _ string) {
+	old, cur, ok := valid.oldAndCurrent(vOf)
+	if !ok {
+		return
+	}
+	if !reflect.DeepEqual(old.Interface(), cur.Interface()) {
+		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), ValidateValImmutable)
+	}
+	return
+}
+
+// oldAndCurrent ValidateUpdate 时字段的旧值及新值, 指针取其指向的值, 新值为 nil 时为零值
+// 没有旧值或旧值为空或零值时 ok 为 false
+func (valid *Validation) oldAndCurrent(vOf reflect.Value) (old, cur reflect.Value, ok bool) {
+	if !valid.oldField.IsValid() {
+		return
+	}
+	if old = indirect(valid.oldField); !old.IsValid() || old.IsZero() {
+		return
+	}
+	if cur = indirect(vOf); !cur.IsValid() {
+		cur = reflect.Zero(old.Type())
+	}
+	return old, cur, true
+}
+
+// RuleTransitions 允许的状态变更, 如 transitions=1>2 2>3, 值不变时不验证, 仅 ValidateUpdate 时验证
+// 旧值为空或零值时允许设置为任意值, 清空时按 旧值>零值 验证, 如 transitions=2>0
+// 支持: int8, int16, int32, int, int64, uint8, uint16, uint32, uint, uint64,
+// string
+func (valid *Validation) RuleTransitions(tOf reflect.StructField, vOf reflect.Value, transitions string) {
+	old, cur, ok := valid.oldAndCurrent(vOf)
+	if !ok {
+		return
+	}
+
+	name, tag := tOf.Name, tOf.Tag.Get(defaultNameTag)
+	if !kindOf(cur.Kind(), ruleSpecs["transitions"].kinds) {
+		valid.SetError(name, tag, fmt.Sprintf(ValidateMethodNotAllowSth, "transitions", cur.Type()))
+		return
+	}
+
+	from, to := fmt.Sprint(old.Interface()), fmt.Sprint(cur.Interface())
+	if from == to {
+		return
+	}
+	for _, t := range strings.Fields(transitions) {
+		pair := strings.Split(t, ">")
+		if len(pair) != 2 {
+			valid.SetError(name, tag, ValidateValTypeErr)
+			return
+		}
+		if pair[0] == from && pair[1] == to {
+			return
+		}
+	}
+	valid.SetError(name, tag, fmt.Sprintf(ValidateValTransitionErr, valid.display(from), valid.display(to)))
+}
+
+// RuleEnum 枚举
+// enum=name 验证值是否在 RegisterEnum 注册的值中, enum 验证类型实现的 Enum 接口 (IsValid() bool)
+// 支持: 枚举类型及其 slice
+func (valid *Validation) RuleEnum(tOf reflect.StructField, vOf reflect.Value, name string) {
+	if vOf.IsZero() {
+		return
+	}
+	if msg := enumError(name, vOf); msg != "" {
+		valid.SetError(tOf.Name, tOf.Tag.Get(defaultNameTag), msg)
+	}
+}
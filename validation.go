@@ -1,8 +1,10 @@
 package gvalid
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sync"
 )
 
 /**
@@ -13,13 +15,81 @@ import (
  */
 
 // ValidCustom 自定义验证
+// 嵌套结构体 (dive) 同样会执行, 指针接收者和值接收者均支持
 type ValidCustom interface {
 	Valid(*Validation)
 }
 
+// ValidCustomCtx 带 context 的自定义验证
+// path 为当前结构体的路径, 如 Address[0], 顶层为空; 返回的 error 用于数据库等基础设施错误, 会中止验证并由 Valid 返回
+type ValidCustomCtx interface {
+	ValidCtx(ctx context.Context, valid *Validation, path string) error
+}
+
+var (
+	validCustomType    = reflect.TypeOf((*ValidCustom)(nil)).Elem()
+	validCustomCtxType = reflect.TypeOf((*ValidCustomCtx)(nil)).Elem()
+)
+
+// StructValidationFunc 结构体级别的验证, obj 为结构体的值 (非指针)
+type StructValidationFunc func(valid *Validation, obj interface{})
+
+var (
+	structValidationsMu sync.RWMutex
+	structValidations   = make(map[reflect.Type][]StructValidationFunc)
+)
+
+// RegisterStructValidation 为 types 注册结构体级别的验证, 用于无法实现 ValidCustom 的类型, 如 proto, 第三方 SDK 中的类型
+// types 为结构体或结构体指针, 在字段规则之后执行, dive 的嵌套结构体同样执行
+func RegisterStructValidation(fn StructValidationFunc, types ...interface{}) {
+	structValidationsMu.Lock()
+	defer structValidationsMu.Unlock()
+	for _, t := range types {
+		tOf := indirectType(reflect.TypeOf(t))
+		structValidations[tOf] = append(structValidations[tOf], fn)
+	}
+}
+
+// structValidationsOf 类型注册的结构体级别的验证
+// 注册只追加, 返回的切片在 len 范围内不会被修改
+func structValidationsOf(t reflect.Type) []StructValidationFunc {
+	structValidationsMu.RLock()
+	defer structValidationsMu.RUnlock()
+	return structValidations[t]
+}
+
 type Validation struct {
-	Errors    []*Error
+	Errors []*Error
+	// ErrorsMap 按字段名 (Error.Field) 分组, 嵌套结构体中的同名字段在同一组
 	ErrorsMap map[string][]*Error
+	// ErrorsByPath 按完整路径 (Error.Path) 分组, 如 Address[0].City
+	ErrorsByPath map[string][]*Error
+
+	// DisallowUnknownFields ValidateJSON 时不允许结构体中不存在的字段
+	DisallowUnknownFields bool
+	// Groups 当前验证场景, 如 create, update
+	// 只验证未指定分组的规则, 及分组属于 Groups 的规则
+	Groups []string
+
+	// path 当前结构体所在路径, 如 Address[0]
+	path string
+	// rule, param, values, value 当前执行的验证规则, 参数, 解析后的参数及字段值
+	rule   string
+	param  string
+	values []string
+	value  reflect.Value
+	// mask 当前字段的脱敏函数
+	mask Masker
+	// old ValidateUpdate 时下一个结构体对应的旧值, oldField 当前字段的旧值
+	old, oldField reflect.Value
+	// parent 当前字段所在的结构体类型, embeddedIn 下一个结构体是匿名嵌入在哪个结构体中
+	parent, embeddedIn reflect.Type
+	// ctx ValidContext 传入的 context
+	ctx context.Context
+	// partial 部分验证, 返回字段本身是否需要验证, 及是否需要验证其下级字段, 为 nil 时全部验证
+	partial func(path string) (self, descend bool)
+	// overrides 本次验证使用的覆盖规则, 验证过程中重新加载不影响本次验证
+	overrides *ruleOverrides
 }
 
 // HasErrors 是否有 Errors 信息
@@ -29,21 +99,118 @@ func (valid *Validation) HasErrors() bool {
 
 // setError 设置 Error
 func (valid *Validation) setError(err *Error) {
+	if err.Path == "" {
+		err.Path = joinPath(valid.path, err.Field)
+	}
+	if err.Rule == "" && valid.rule != "" {
+		err.Rule, err.Param, err.Params = valid.rule, valid.param, valid.values
+		if vOf := indirect(valid.value); vOf.IsValid() {
+			err.Kind = vOf.Kind().String()
+			if vOf.CanInterface() {
+				err.Value = vOf.Interface()
+			}
+		}
+		// 错误信息中的字段值由规则通过 display 脱敏
+		if valid.mask != nil && err.Value != nil {
+			err.Value = maskValue(err.Value, valid.mask)
+		}
+	}
+	if err.Rule == "" {
+		err.Rule = CustomRule
+	}
+	// 部分验证时忽略 ValidCustom 等对其它字段设置的 Error
+	if self, _ := valid.match(err.Path); !self {
+		return
+	}
+	if err.Code == "" {
+		err.Code = ErrorCode(err.Rule)
+	}
 	valid.Errors = append(valid.Errors, err)
 	if valid.ErrorsMap == nil {
 		valid.ErrorsMap = make(map[string][]*Error)
-	}
-	if _, ok := valid.ErrorsMap[err.Field]; !ok {
-		valid.ErrorsMap[err.Field] = []*Error{}
+		valid.ErrorsByPath = make(map[string][]*Error)
 	}
 	valid.ErrorsMap[err.Field] = append(valid.ErrorsMap[err.Field], err)
+	valid.ErrorsByPath[err.Path] = append(valid.ErrorsByPath[err.Path], err)
+}
+
+// match 字段是否需要验证
+func (valid *Validation) match(path string) (self, descend bool) {
+	if valid.partial == nil {
+		return true, true
+	}
+	return valid.partial(path)
+}
+
+// inGroups 规则是否属于当前验证场景
+func (valid *Validation) inGroups(groups []string) bool {
+	if len(groups) == 0 {
+		return true
+	}
+	for _, g := range groups {
+		for _, active := range valid.Groups {
+			if g == active {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterErrors 保留 keep 返回 true 的 Error
+func (valid *Validation) filterErrors(keep func(*Error) bool) {
+	errs := valid.Errors
+	valid.Errors, valid.ErrorsMap, valid.ErrorsByPath = nil, nil, nil
+	for _, err := range errs {
+		if keep(err) {
+			valid.setError(err)
+		}
+	}
 }
 
 // SetError 设置 Error
+// fieldName 为当前结构体中的字段名, 嵌套结构体中会自动拼接完整路径
 func (valid *Validation) SetError(fieldName string, name string, msg string) {
 	valid.setError(&Error{Field: fieldName, Name: name, Message: msg})
 }
 
+// ValidContext 验证, ctx 传递给 ValidCustomCtx
+func (valid *Validation) ValidContext(ctx context.Context, obj interface{}) (b bool, err error) {
+	valid.ctx = ctx
+	defer func() { valid.ctx = nil }()
+	return valid.Valid(obj)
+}
+
+// validCustom 执行 ValidCustom, ValidCustomCtx
+// vOf 不可寻址时复制一份, 以便调用指针接收者的方法
+// 匿名嵌入的结构体, 方法已提升到外层结构体的, 由外层执行, 避免重复验证
+func (valid *Validation) validCustom(vOf reflect.Value, embeddedIn reflect.Type) error {
+	if !vOf.CanInterface() {
+		return nil
+	}
+	if !vOf.CanAddr() {
+		cp := reflect.New(vOf.Type()).Elem()
+		cp.Set(vOf)
+		vOf = cp
+	}
+	ptr := vOf.Addr()
+	promoted := func(iface reflect.Type) bool {
+		return embeddedIn != nil && reflect.PtrTo(embeddedIn).Implements(iface)
+	}
+
+	if form, ok := ptr.Interface().(ValidCustom); ok && !promoted(validCustomType) {
+		form.Valid(valid)
+	}
+	if form, ok := ptr.Interface().(ValidCustomCtx); ok && !promoted(validCustomCtxType) {
+		ctx := valid.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return form.ValidCtx(ctx, valid, valid.path)
+	}
+	return nil
+}
+
 // Valid 验证
 func (valid *Validation) Valid(obj interface{}) (b bool, err error) {
 	var vOf reflect.Value
@@ -64,20 +231,85 @@ func (valid *Validation) Valid(obj interface{}) (b bool, err error) {
 		return
 	}
 
+	if valid.overrides == nil {
+		valid.overrides = loadOverrides()
+		defer func() { valid.overrides = nil }()
+	}
+
+	old := indirect(valid.old)
+	if old.IsValid() && old.Type() != tOf {
+		old = reflect.Value{}
+	}
+	embeddedIn := valid.embeddedIn
+	valid.old, valid.embeddedIn = reflect.Value{}, nil
+	defer func() { valid.oldField = reflect.Value{} }()
+
 	for i := 0; i < tOf.NumField(); i++ {
+		valid.parent = tOf
+		if old.IsValid() {
+			valid.oldField = old.Field(i)
+			if cv, ok := customValue(valid.oldField); ok {
+				valid.oldField = cv
+			}
+		}
+		// 自定义类型验证其实际值, dive 仍使用原字段
+		sf := labeledField(tOf, tOf.Field(i))
+		fOf, fvOf := sf, vOf.Field(i)
+		if cv, ok := customValue(fvOf); ok {
+			fOf.Type, fvOf = cv.Type(), cv
+		}
+		self, descend := valid.match(fieldPath(valid.path, tOf.Field(i)))
+		if !self && !descend {
+			continue
+		}
 		var vfs []ValidFunc
-		if vfs, err = matchValidFunc(tOf.Field(i)); err != nil {
+		if vfs, err = matchValidFunc(tOf, tOf.Field(i), valid.overrides); err != nil {
+			return
+		}
+		if valid.mask, err = fieldMasker(tOf.Field(i), vfs); err != nil {
 			return
 		}
 		for _, vf := range vfs {
-			if _, err = validFuncMap.Call(vf.Name, valid, tOf.Field(i), vOf.Field(i), vf.Params); err != nil {
+			if vf.Name == validFuncPrefix+toUpperCamel(SensitiveTag) {
+				continue
+			}
+			// 只需验证下级字段时仅执行 dive
+			if !self && vf.Name != diveFunc {
+				continue
+			}
+			if !valid.inGroups(vf.Groups) {
+				continue
+			}
+			ft, fv := fOf, fvOf
+			if vf.Name == diveFunc {
+				ft, fv = sf, vOf.Field(i)
+			}
+			valid.rule, valid.param, valid.values, valid.value = ruleName(vf.Name), vf.Param(), vf.Values, fv
+			var result []reflect.Value
+			if valid.kindAllowed(ft, fv) {
+				result, err = validFuncMap.Call(vf.Name, valid, ft, fv, vf.Params)
+			}
+			valid.rule, valid.param, valid.values, valid.value = "", "", nil, reflect.Value{}
+			if err == nil && len(result) > 0 {
+				err, _ = result[0].Interface().(error)
+			}
+			if err != nil {
 				return
 			}
 		}
+		valid.mask = nil
 	}
+	// dive 会修改 parent, 结构体级别的验证及 ValidCustom 中 Mask 使用当前结构体
+	valid.parent = tOf
 
-	if form, ok := obj.(ValidCustom); ok {
-		form.Valid(valid)
+	if fns := structValidationsOf(tOf); len(fns) > 0 && vOf.CanInterface() {
+		for _, fn := range fns {
+			fn(valid, vOf.Interface())
+		}
+	}
+
+	if err = valid.validCustom(vOf, embeddedIn); err != nil {
+		return
 	}
 
 	return !valid.HasErrors(), nil
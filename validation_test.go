@@ -2,6 +2,7 @@ package gvalid
 
 import (
 	"flag"
+	"sync"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -1138,3 +1139,95 @@ func TestDive(t *testing.T) {
 		t.Fatal("result valid err:", v.ErrorsMap)
 	}
 }
+
+func TestErrorPath(t *testing.T) {
+	type Address struct {
+		City string `valid:"required" name:"市"`
+	}
+	type WUser struct {
+		Pager
+		Name    string     `valid:"required" name:"姓名"`
+		Home    *Address   `valid:"dive" name:"家庭地址"`
+		Address []*Address `valid:"dive" name:"地址"`
+	}
+
+	u := &WUser{Pager: Pager{Page: 1}, Address: []*Address{{City: "上海"}, {}}}
+	v := &Validation{}
+	b, err := v.Valid(u)
+	if err != nil {
+		t.Fatal("result err:", err)
+	}
+
+	Convey("test error path", t, func() {
+		So(b, ShouldBeFalse)
+		So(len(v.Errors), ShouldEqual, 4)
+		So(v.ErrorsMap["PageSize"][0].Rule, ShouldEqual, "required")
+		So(v.ErrorsMap["Name"][0].Path, ShouldEqual, "Name")
+		So(v.ErrorsByPath["Home.City"][0].Field, ShouldEqual, "City")
+		So(v.ErrorsByPath["Address[1].City"][0].Name, ShouldEqual, "市")
+		So(v.ErrorsByPath["Address[0].City"], ShouldBeNil)
+		So(v.ErrorsMap["City"], ShouldHaveLength, 2)
+		So(v.ErrorsMap["Home.City"], ShouldBeNil)
+	})
+}
+
+func TestErrorDetail(t *testing.T) {
+	type WUser struct {
+		Age    int    `valid:"gte=10" name:"年龄"`
+		Num    *int   `valid:"lt=3" name:"数量"`
+		IdCard string `valid:"idCard" name:"身份证"`
+		Mode   []int  `valid:"sin=0 1" name:"模式"`
+		Size   string `valid:"in='X L' M" name:"尺码"`
+	}
+
+	SetErrorCode("sin", "E1001")
+	defer delete(errorCodes, "sin")
+
+	n := 5
+	u := &WUser{Age: 9, Num: &n, IdCard: "1989898989898989", Mode: []int{2}, Size: "S"}
+	v := &Validation{}
+	b, err := v.Valid(u)
+	if err != nil {
+		t.Fatal("result err:", err)
+	}
+	v.SetError("Age", "年龄", "自定义")
+
+	Convey("test error detail", t, func() {
+		So(b, ShouldBeFalse)
+		So(v.ErrorsMap["Age"][0], ShouldResemble, &Error{Field: "Age", Name: "年龄", Message: "必须是大于等于 10",
+			Path: "Age", Rule: "gte", Param: "10", Params: []string{"10"}, Kind: "int", Value: 9, Code: "ERR_GTE"})
+		So(v.ErrorsMap["Num"][0].Value, ShouldEqual, 5)
+		So(v.ErrorsMap["IdCard"][0].Code, ShouldEqual, "ERR_ID_CARD")
+		So(v.ErrorsMap["Mode"][0].Kind, ShouldEqual, "slice")
+		So(v.ErrorsMap["Mode"][0].Param, ShouldEqual, "0 1")
+		So(v.ErrorsMap["Mode"][0].Code, ShouldEqual, "E1001")
+		So(v.ErrorsMap["Size"][0].Params, ShouldResemble, []string{"X L", "M"})
+		So(v.ErrorsMap["Age"][1].Rule, ShouldEqual, CustomRule)
+		So(v.ErrorsMap["Age"][1].Code, ShouldEqual, "ERR_CUSTOM")
+		So(ErrorCode("sin"), ShouldEqual, "E1001")
+	})
+
+	Convey("test concurrent error codes", t, func() {
+		defer func() {
+			errorCodesMu.Lock()
+			delete(errorCodes, "gte")
+			errorCodesMu.Unlock()
+		}()
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				SetErrorCode("gte", "E1002")
+			}()
+			go func() {
+				defer wg.Done()
+				_, _ = (&Validation{}).Valid(u)
+			}()
+		}
+		wg.Wait()
+		v := &Validation{}
+		_, _ = v.Valid(u)
+		So(v.ErrorsMap["Age"][0].Code, ShouldEqual, "E1002")
+	})
+}
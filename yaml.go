@@ -0,0 +1,154 @@
+package gvalid
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2026/10/22 15:30
+ * @Desc: JSON 转 YAML, 保持字段顺序, 不依赖第三方库
+ */
+
+// orderedMap 保持键顺序的对象, 由 decodeOrderedJSON 及 parseYAML 生成
+type orderedMap []orderedEntry
+
+type orderedEntry struct {
+	key   string
+	value interface{}
+}
+
+// jsonToYAML JSON 转为块格式的 YAML, 字符串使用双引号
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := decodeOrderedJSON(dec)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	writeYAML(&buf, v, 0, false)
+	return buf.Bytes(), nil
+}
+
+// decodeOrderedJSON 解码 JSON, 保持对象的键顺序, 对象为 orderedMap, 数组为 []interface{}
+func decodeOrderedJSON(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		m := orderedMap{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, orderedEntry{key: key.(string), value: value})
+		}
+		_, err = dec.Token()
+		return m, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for dec.More() {
+			value, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = dec.Token()
+		return list, err
+	}
+	return tok, nil
+}
+
+// writeYAML inline 为 true 时第一行不缩进, 用于 "- " 之后
+func writeYAML(buf *bytes.Buffer, v interface{}, indent int, inline bool) {
+	pad := strings.Repeat("  ", indent)
+	switch v := v.(type) {
+	case orderedMap:
+		for i, e := range v {
+			if i > 0 || !inline {
+				buf.WriteString(pad)
+			}
+			buf.WriteString(yamlKey(e.key) + ":")
+			writeYAMLValue(buf, e.value, indent+1)
+		}
+	case []interface{}:
+		for i, item := range v {
+			if i > 0 || !inline {
+				buf.WriteString(pad)
+			}
+			buf.WriteString("-")
+			if isYAMLBlock(item) {
+				buf.WriteString(" ")
+				writeYAML(buf, item, indent+1, true)
+			} else {
+				buf.WriteString(" " + yamlScalar(item) + "\n")
+			}
+		}
+	}
+}
+
+// writeYAMLValue 写入 key: 之后的值
+func writeYAMLValue(buf *bytes.Buffer, v interface{}, indent int) {
+	if !isYAMLBlock(v) {
+		buf.WriteString(" " + yamlScalar(v) + "\n")
+		return
+	}
+	buf.WriteString("\n")
+	writeYAML(buf, v, indent, false)
+}
+
+// isYAMLBlock 非空的对象和数组使用块格式
+func isYAMLBlock(v interface{}) bool {
+	switch v := v.(type) {
+	case orderedMap:
+		return len(v) > 0
+	case []interface{}:
+		return len(v) > 0
+	}
+	return false
+}
+
+func yamlScalar(v interface{}) string {
+	switch v := v.(type) {
+	case orderedMap:
+		return "{}"
+	case []interface{}:
+		return "[]"
+	case string:
+		return strconv.Quote(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return "null"
+}
+
+var yamlPlainKey = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$-]*$`)
+
+// yamlKey 只包含字母数字的键不加引号
+func yamlKey(key string) string {
+	if yamlPlainKey.MatchString(key) && !yamlReserved[strings.ToLower(key)] {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// yamlReserved 不加引号时会被解析为 bool 或 null 的键
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true, "null": true, "y": true, "n": true,
+}